@@ -7,15 +7,18 @@ import (
 	"net/http"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/pathorcontents"
 	"github.com/hashicorp/terraform/terraform"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"golang.org/x/oauth2/jwt"
+	"google.golang.org/api/bigquery/v2"
 	"google.golang.org/api/cloudresourcemanager/v1"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/container/v1"
+	"google.golang.org/api/dataflow/v1b3"
 	"google.golang.org/api/dns/v1"
 	"google.golang.org/api/pubsub/v1"
 	"google.golang.org/api/sqladmin/v1beta4"
@@ -26,11 +29,39 @@ import (
 // provider.
 type Config struct {
 	Credentials string
+	AccessToken string
 	Project     string
 	Region      string
+	MaxRetries  int
 
+	// ImpersonateServiceAccount is the email of a service account to act
+	// as. The configured credentials are only used to mint tokens for it.
+	ImpersonateServiceAccount string
+
+	// DefaultLabels are added to the labels of every resource that
+	// supports them, unless the resource sets the same key itself.
+	DefaultLabels map[string]string
+
+	// RequestTimeout bounds each API call, including any retries. Zero
+	// means no timeout.
+	RequestTimeout time.Duration
+
+	// Custom endpoints replace the default BasePath of the matching API
+	// client, e.g. to talk to a local emulator or a private endpoint.
+	BigQueryEndpoint        string
+	ComputeEndpoint         string
+	ContainerEndpoint       string
+	DataflowEndpoint        string
+	DnsEndpoint             string
+	PubsubEndpoint          string
+	ResourceManagerEndpoint string
+	SqlAdminEndpoint        string
+	StorageEndpoint         string
+
+	clientBigQuery        *bigquery.Service
 	clientCompute         *compute.Service
 	clientContainer       *container.Service
+	clientDataflow        *dataflow.Service
 	clientDns             *dns.Service
 	clientPubsub          *pubsub.Service
 	clientResourceManager *cloudresourcemanager.Service
@@ -49,7 +80,12 @@ func (c *Config) loadAndValidate() error {
 
 	var client *http.Client
 
-	if c.Credentials != "" {
+	if c.AccessToken != "" {
+		log.Printf("[INFO] Authenticating using configured access token")
+		token := &oauth2.Token{AccessToken: c.AccessToken}
+		client = oauth2.NewClient(oauth2.NoContext, oauth2.StaticTokenSource(token))
+
+	} else if c.Credentials != "" {
 		contents, _, err := pathorcontents.Read(c.Credentials)
 		if err != nil {
 			return fmt.Errorf("Error loading credentials: %s", err)
@@ -87,6 +123,29 @@ func (c *Config) loadAndValidate() error {
 		}
 	}
 
+	if c.ImpersonateServiceAccount != "" {
+		log.Printf("[INFO] Impersonating service account %s", c.ImpersonateServiceAccount)
+
+		// Minting tokens for the target account talks to the IAM API and
+		// the token endpoint outside of the API clients, so give those
+		// requests the same retries and timeout.
+		client.Transport = newRetryTransport(client.Transport, c.MaxRetries)
+		client.Timeout = c.RequestTimeout
+		tokenClient := &http.Client{
+			Transport: newRetryTransport(nil, c.MaxRetries),
+			Timeout:   c.RequestTimeout,
+		}
+
+		ts, err := newImpersonatedTokenSource(client, tokenClient, c.ImpersonateServiceAccount, clientScopes)
+		if err != nil {
+			return err
+		}
+		client = oauth2.NewClient(oauth2.NoContext, ts)
+	}
+
+	client.Transport = newRetryTransport(client.Transport, c.MaxRetries)
+	client.Timeout = c.RequestTimeout
+
 	versionString := terraform.VersionString()
 	userAgent := fmt.Sprintf(
 		"(%s %s) Terraform/%s", runtime.GOOS, runtime.GOARCH, versionString)
@@ -99,6 +158,9 @@ func (c *Config) loadAndValidate() error {
 		return err
 	}
 	c.clientCompute.UserAgent = userAgent
+	if c.ComputeEndpoint != "" {
+		c.clientCompute.BasePath = c.ComputeEndpoint
+	}
 
 	log.Printf("[INFO] Instantiating GKE client...")
 	c.clientContainer, err = container.New(client)
@@ -106,6 +168,29 @@ func (c *Config) loadAndValidate() error {
 		return err
 	}
 	c.clientContainer.UserAgent = userAgent
+	if c.ContainerEndpoint != "" {
+		c.clientContainer.BasePath = c.ContainerEndpoint
+	}
+
+	log.Printf("[INFO] Instantiating Google BigQuery client...")
+	c.clientBigQuery, err = bigquery.New(client)
+	if err != nil {
+		return err
+	}
+	c.clientBigQuery.UserAgent = userAgent
+	if c.BigQueryEndpoint != "" {
+		c.clientBigQuery.BasePath = c.BigQueryEndpoint
+	}
+
+	log.Printf("[INFO] Instantiating Google Dataflow client...")
+	c.clientDataflow, err = dataflow.New(client)
+	if err != nil {
+		return err
+	}
+	c.clientDataflow.UserAgent = userAgent
+	if c.DataflowEndpoint != "" {
+		c.clientDataflow.BasePath = c.DataflowEndpoint
+	}
 
 	log.Printf("[INFO] Instantiating Google Cloud DNS client...")
 	c.clientDns, err = dns.New(client)
@@ -113,6 +198,9 @@ func (c *Config) loadAndValidate() error {
 		return err
 	}
 	c.clientDns.UserAgent = userAgent
+	if c.DnsEndpoint != "" {
+		c.clientDns.BasePath = c.DnsEndpoint
+	}
 
 	log.Printf("[INFO] Instantiating Google Storage Client...")
 	c.clientStorage, err = storage.New(client)
@@ -120,6 +208,9 @@ func (c *Config) loadAndValidate() error {
 		return err
 	}
 	c.clientStorage.UserAgent = userAgent
+	if c.StorageEndpoint != "" {
+		c.clientStorage.BasePath = c.StorageEndpoint
+	}
 
 	log.Printf("[INFO] Instantiating Google SqlAdmin Client...")
 	c.clientSqlAdmin, err = sqladmin.New(client)
@@ -127,6 +218,9 @@ func (c *Config) loadAndValidate() error {
 		return err
 	}
 	c.clientSqlAdmin.UserAgent = userAgent
+	if c.SqlAdminEndpoint != "" {
+		c.clientSqlAdmin.BasePath = c.SqlAdminEndpoint
+	}
 
 	log.Printf("[INFO] Instatiating Google Pubsub Client...")
 	c.clientPubsub, err = pubsub.New(client)
@@ -134,6 +228,9 @@ func (c *Config) loadAndValidate() error {
 		return err
 	}
 	c.clientPubsub.UserAgent = userAgent
+	if c.PubsubEndpoint != "" {
+		c.clientPubsub.BasePath = c.PubsubEndpoint
+	}
 
 	log.Printf("[INFO] Instatiating Google CloudResourceManager Client...")
 	c.clientResourceManager, err = cloudresourcemanager.New(client)
@@ -141,6 +238,9 @@ func (c *Config) loadAndValidate() error {
 		return err
 	}
 	c.clientPubsub.UserAgent = userAgent
+	if c.ResourceManagerEndpoint != "" {
+		c.clientResourceManager.BasePath = c.ResourceManagerEndpoint
+	}
 
 	return nil
 }
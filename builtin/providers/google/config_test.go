@@ -3,6 +3,7 @@ package google
 import (
 	"io/ioutil"
 	"testing"
+	"time"
 )
 
 const testFakeCredentialsPath = "./test-fixtures/fake_account.json"
@@ -48,3 +49,42 @@ func TestConfigLoadAndValidate_accountFileJSONInvalid(t *testing.T) {
 		t.Fatalf("expected error, but got nil")
 	}
 }
+
+func TestConfigLoadAndValidate_customEndpoints(t *testing.T) {
+	config := Config{
+		Credentials:      testFakeCredentialsPath,
+		Project:          "my-gce-project",
+		Region:           "us-central1",
+		RequestTimeout:   30 * time.Second,
+		DataflowEndpoint: "http://localhost:8085/",
+		PubsubEndpoint:   "http://localhost:8086/",
+	}
+
+	err := config.loadAndValidate()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if config.clientDataflow.BasePath != "http://localhost:8085/" {
+		t.Fatalf("expected dataflow endpoint to be overridden, got %q", config.clientDataflow.BasePath)
+	}
+	if config.clientPubsub.BasePath != "http://localhost:8086/" {
+		t.Fatalf("expected pubsub endpoint to be overridden, got %q", config.clientPubsub.BasePath)
+	}
+	if config.clientStorage.BasePath != "https://www.googleapis.com/storage/v1/" {
+		t.Fatalf("expected default storage endpoint, got %q", config.clientStorage.BasePath)
+	}
+}
+
+func TestConfigLoadAndValidate_accessToken(t *testing.T) {
+	config := Config{
+		AccessToken: "foo",
+		Project:     "my-gce-project",
+		Region:      "us-central1",
+	}
+
+	err := config.loadAndValidate()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package google
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/dataflow/v1b3"
+)
+
+// dataSourceGoogleDataflowJob returns a *schema.Resource that looks up an
+// active Dataflow job by name, so that jobs launched outside of Terraform can
+// be referenced by other resources:
+//
+// data "google_dataflow_job" "wordcount" {
+//   name = "wordcount-nightly"
+// }
+func dataSourceGoogleDataflowJob() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGoogleDataflowJobRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"state": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceGoogleDataflowJobRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	// Dataflow only guarantees that job names are unique among active jobs,
+	// so restrict the listing to those.
+	var job *dataflow.Job
+	token := ""
+	for job == nil {
+		call := config.clientDataflow.Projects.Jobs.List(project).Filter("ACTIVE")
+		if token != "" {
+			call = call.PageToken(token)
+		}
+
+		res, err := call.Do()
+		if err != nil {
+			return fmt.Errorf("Error listing Dataflow jobs in project %s: %s", project, err)
+		}
+
+		for _, j := range res.Jobs {
+			if j.Name == name {
+				job = j
+				break
+			}
+		}
+
+		token = res.NextPageToken
+		if token == "" {
+			break
+		}
+	}
+
+	if job == nil {
+		return fmt.Errorf("No active Dataflow job named %q found in project %s", name, project)
+	}
+
+	d.SetId(job.Id)
+	d.Set("project", project)
+	d.Set("state", job.CurrentState)
+	d.Set("type", job.Type)
+	d.Set("create_time", job.CreateTime)
+
+	return nil
+}
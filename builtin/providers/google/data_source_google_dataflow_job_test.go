@@ -0,0 +1,110 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"google.golang.org/api/dataflow/v1b3"
+)
+
+func TestAccGoogleDataflowJob_notFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccGoogleDataflowJob_notFound,
+				ExpectError: regexp.MustCompile("No active Dataflow job named"),
+			},
+		},
+	})
+}
+
+var testAccGoogleDataflowJob_notFound = fmt.Sprintf(`
+data "google_dataflow_job" "missing" {
+	name = "dfjob-test-%s"
+}`, acctest.RandString(10))
+
+func TestDataSourceGoogleDataflowJobRead(t *testing.T) {
+	// The job looked for is on the second page, so that paging is covered.
+	pages := map[string]*dataflow.ListJobsResponse{
+		"": &dataflow.ListJobsResponse{
+			Jobs: []*dataflow.Job{
+				{Id: "2016-08-01_00_00_00-1", Name: "other-job"},
+			},
+			NextPageToken: "page-2",
+		},
+		"page-2": &dataflow.ListJobsResponse{
+			Jobs: []*dataflow.Job{
+				{Id: "2016-08-01_00_00_00-2", Name: "another-job"},
+				{
+					Id:           "2016-08-01_00_00_00-3",
+					Name:         "wordcount",
+					CurrentState: "JOB_STATE_RUNNING",
+					Type:         "JOB_TYPE_STREAMING",
+					CreateTime:   "2016-08-01T00:00:00.000Z",
+				},
+			},
+		},
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1b3/projects/my-project/jobs" {
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+		if filter := r.URL.Query().Get("filter"); filter != "ACTIVE" {
+			t.Errorf("expected only active jobs to be listed, got filter %q", filter)
+		}
+
+		page, ok := pages[r.URL.Query().Get("pageToken")]
+		if !ok {
+			t.Errorf("unexpected page token %q", r.URL.Query().Get("pageToken"))
+			page = &dataflow.ListJobsResponse{}
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer ts.Close()
+
+	client, err := dataflow.New(http.DefaultClient)
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	client.BasePath = ts.URL + "/"
+	config := &Config{
+		Project:        "my-project",
+		clientDataflow: client,
+	}
+
+	d := dataSourceGoogleDataflowJob().Data(nil)
+	d.Set("name", "wordcount")
+	if err := dataSourceGoogleDataflowJobRead(d, config); err != nil {
+		t.Fatalf("error: %s", err)
+	}
+
+	expected := map[string]string{
+		"project":     "my-project",
+		"state":       "JOB_STATE_RUNNING",
+		"type":        "JOB_TYPE_STREAMING",
+		"create_time": "2016-08-01T00:00:00.000Z",
+	}
+	if d.Id() != "2016-08-01_00_00_00-3" {
+		t.Fatalf("expected ID %q, got %q", "2016-08-01_00_00_00-3", d.Id())
+	}
+	for k, v := range expected {
+		if actual := d.Get(k).(string); actual != v {
+			t.Fatalf("expected %s to be %q, got %q", k, v, actual)
+		}
+	}
+
+	d = dataSourceGoogleDataflowJob().Data(nil)
+	d.Set("name", "missing")
+	if err := dataSourceGoogleDataflowJobRead(d, config); err == nil {
+		t.Fatalf("expected an error for a job that doesn't exist")
+	}
+}
@@ -0,0 +1,103 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"google.golang.org/api/dataflow/v1b3"
+)
+
+// Sweepers remove resources leaked by failed acceptance test runs. Run them
+// with:
+//
+//	go test ./builtin/providers/google -v -sweep=us-central1
+//
+// or a subset of them with -sweep-run=gcp_storage_bucket. Sweepers only touch
+// resources whose names carry the prefixes used by the acceptance tests.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// sharedConfigForRegion returns a Config for the project given in the
+// environment, in the same way the acceptance tests are configured.
+func sharedConfigForRegion(region string) (*Config, error) {
+	project := multiEnvSearch([]string{
+		"GOOGLE_PROJECT",
+		"GCLOUD_PROJECT",
+		"CLOUDSDK_CORE_PROJECT",
+	})
+	if project == "" {
+		return nil, fmt.Errorf("empty GOOGLE_PROJECT")
+	}
+
+	credentials := multiEnvSearch([]string{
+		"GOOGLE_CREDENTIALS",
+		"GOOGLE_CLOUD_KEYFILE_JSON",
+		"GCLOUD_KEYFILE_JSON",
+	})
+
+	config := &Config{
+		Credentials: credentials,
+		Project:     project,
+		Region:      region,
+		MaxRetries:  5,
+	}
+
+	if err := config.loadAndValidate(); err != nil {
+		return nil, fmt.Errorf("error loading config: %s", err)
+	}
+
+	return config, nil
+}
+
+func init() {
+	resource.AddTestSweepers("gcp_dataflow_job", &resource.Sweeper{
+		Name: "gcp_dataflow_job",
+		F:    testSweepDataflowJobs,
+	})
+}
+
+// testSweepDataflowJobs cancels running jobs left behind by tests. Dataflow
+// jobs can't be deleted, but cancelling them stops their workers.
+func testSweepDataflowJobs(region string) error {
+	config, err := sharedConfigForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	pageToken := ""
+	for {
+		call := config.clientDataflow.Projects.Jobs.List(config.Project).Filter("ACTIVE")
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		res, err := call.Do()
+		if err != nil {
+			return fmt.Errorf("Error listing Dataflow jobs: %s", err)
+		}
+
+		for _, job := range res.Jobs {
+			if !strings.HasPrefix(job.Name, "dfjob-test-") {
+				continue
+			}
+
+			log.Printf("[INFO] Sweeping Dataflow job %s (%s)", job.Name, job.Id)
+			update := &dataflow.Job{
+				RequestedState: "JOB_STATE_CANCELLED",
+			}
+			if _, err := config.clientDataflow.Projects.Jobs.Update(config.Project, job.Id, update).Do(); err != nil {
+				return fmt.Errorf("Error cancelling Dataflow job %s: %s", job.Name, err)
+			}
+		}
+
+		if res.NextPageToken == "" {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+
+	return nil
+}
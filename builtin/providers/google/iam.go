@@ -0,0 +1,166 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/terraform/helper/mutexkv"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// ResourceIamUpdater is implemented for every kind of resource that has an
+// IAM policy. The generic google_*_iam_policy, google_*_iam_binding and
+// google_*_iam_member resources only talk to the API through it.
+//
+// Policies are passed around as cloudresourcemanager.Policy values. Every
+// API shares the same policy layout, so implementations for other services
+// convert to and from it.
+type ResourceIamUpdater interface {
+	// GetResourceIamPolicy fetches the current IAM policy of the resource.
+	GetResourceIamPolicy() (*cloudresourcemanager.Policy, error)
+
+	// SetResourceIamPolicy replaces the IAM policy of the resource. The
+	// policy's etag must be sent back unchanged, so that concurrent
+	// modifications are detected.
+	SetResourceIamPolicy(policy *cloudresourcemanager.Policy) error
+
+	// GetResourceId returns a string that uniquely identifies the
+	// resource, used both as the Terraform ID prefix and as a lock key.
+	GetResourceId() string
+
+	// DescribeResource returns a human readable description of the
+	// resource for use in error messages.
+	DescribeResource() string
+
+	// ClearPolicyOnDelete reports whether destroying a google_*_iam_policy
+	// resource should remove every binding from the resource's policy, or
+	// leave the policy as it is.
+	ClearPolicyOnDelete() bool
+}
+
+type newResourceIamUpdaterFunc func(d *schema.ResourceData, config *Config) (ResourceIamUpdater, error)
+
+type iamPolicyModifyFunc func(p *cloudresourcemanager.Policy) error
+
+// resourceIdParserFunc sets the parent-specific fields of an IAM resource
+// being imported from the ID of its parent resource.
+type resourceIdParserFunc func(d *schema.ResourceData, id string, config *Config) error
+
+// iamMutexKV serializes read-modify-write cycles on the same resource's
+// policy, so that binding and member resources for the same resource don't
+// race each other within a single run.
+var iamMutexKV = mutexkv.NewMutexKV()
+
+// iamPolicyReadModifyWrite fetches the current policy of the resource,
+// applies modify to it and writes it back. If the write loses a race with
+// another writer outside of Terraform, the whole cycle is retried.
+func iamPolicyReadModifyWrite(updater ResourceIamUpdater, modify iamPolicyModifyFunc) error {
+	key := updater.GetResourceId()
+	iamMutexKV.Lock(key)
+	defer iamMutexKV.Unlock(key)
+
+	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+		p, err := updater.GetResourceIamPolicy()
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		log.Printf("[DEBUG] Retrieved policy for %s: %#v", updater.DescribeResource(), p)
+
+		if err := modify(p); err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		err = updater.SetResourceIamPolicy(p)
+		if err != nil {
+			if isConflictError(err) {
+				log.Printf("[DEBUG] Concurrent modification of policy for %s, retrying", updater.DescribeResource())
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		log.Printf("[DEBUG] Set policy for %s: %#v", updater.DescribeResource(), p)
+
+		return nil
+	})
+}
+
+// isConflictError reports whether err, or an error it wraps, is the 409
+// returned when a policy's etag no longer matches.
+func isConflictError(err error) bool {
+	if gerr, ok := err.(*googleapi.Error); ok {
+		return gerr.Code == http.StatusConflict
+	}
+	if gerr, ok := errwrap.GetType(err, &googleapi.Error{}).(*googleapi.Error); ok {
+		return gerr.Code == http.StatusConflict
+	}
+	return false
+}
+
+// unmarshalIamPolicy parses policy_data as produced by the google_iam_policy
+// data source.
+func unmarshalIamPolicy(policyData string) (*cloudresourcemanager.Policy, error) {
+	policy := &cloudresourcemanager.Policy{}
+	if err := json.Unmarshal([]byte(policyData), policy); err != nil {
+		return nil, fmt.Errorf("Could not unmarshal policy data %q: %s", policyData, err)
+	}
+	return policy, nil
+}
+
+// marshalIamPolicy serializes the bindings of a policy the same way the
+// google_iam_policy data source does, leaving out the etag and version.
+func marshalIamPolicy(policy *cloudresourcemanager.Policy) (string, error) {
+	pdBytes, err := json.Marshal(&cloudresourcemanager.Policy{
+		Bindings: policy.Bindings,
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error marshaling IAM policy: %s", err)
+	}
+	return string(pdBytes), nil
+}
+
+// iamPolicyDataDiffSuppress treats two serialized policies as equal when
+// they grant the same members the same roles, regardless of ordering.
+func iamPolicyDataDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	oldPolicy, err := unmarshalIamPolicy(old)
+	if err != nil {
+		return false
+	}
+	newPolicy, err := unmarshalIamPolicy(new)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(
+		rolesToMembersMap(oldPolicy.Bindings),
+		rolesToMembersMap(newPolicy.Bindings))
+}
+
+// convertIamPolicy copies an IAM policy of one API's type into another's,
+// e.g. from a pubsub.Policy to a cloudresourcemanager.Policy. The policy
+// types of the generated clients are identical on the wire.
+func convertIamPolicy(from, to interface{}) error {
+	b, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
+
+func mergeSchemas(a, b map[string]*schema.Schema) map[string]*schema.Schema {
+	merged := make(map[string]*schema.Schema)
+	for k, v := range a {
+		merged[k] = v
+	}
+	for k, v := range b {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,76 @@
+package google
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+var IamProjectSchema = map[string]*schema.Schema{
+	"project": &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+	},
+}
+
+type ProjectIamUpdater struct {
+	project string
+	config  *Config
+}
+
+func NewProjectIamUpdater(d *schema.ResourceData, config *Config) (ResourceIamUpdater, error) {
+	project, err := getProject(d, config)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("project", project)
+
+	return &ProjectIamUpdater{
+		project: project,
+		config:  config,
+	}, nil
+}
+
+func ProjectIdParseFunc(d *schema.ResourceData, id string, _ *Config) error {
+	d.Set("project", id)
+	return nil
+}
+
+func (u *ProjectIamUpdater) GetResourceIamPolicy() (*cloudresourcemanager.Policy, error) {
+	p, err := u.config.clientResourceManager.Projects.GetIamPolicy(u.project,
+		&cloudresourcemanager.GetIamPolicyRequest{}).Do()
+	if err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf("Error retrieving IAM policy for %s: {{err}}", u.DescribeResource()), err)
+	}
+
+	return p, nil
+}
+
+func (u *ProjectIamUpdater) SetResourceIamPolicy(policy *cloudresourcemanager.Policy) error {
+	_, err := u.config.clientResourceManager.Projects.SetIamPolicy(u.project,
+		&cloudresourcemanager.SetIamPolicyRequest{Policy: policy}).Do()
+	if err != nil {
+		return errwrap.Wrapf(fmt.Sprintf("Error setting IAM policy for %s: {{err}}", u.DescribeResource()), err)
+	}
+
+	return nil
+}
+
+func (u *ProjectIamUpdater) GetResourceId() string {
+	return u.project
+}
+
+func (u *ProjectIamUpdater) DescribeResource() string {
+	return fmt.Sprintf("project %q", u.project)
+}
+
+// Clearing the policy of a project would also remove its owners and could
+// lock everyone out of it, so destroying the policy resource leaves it alone.
+func (u *ProjectIamUpdater) ClearPolicyOnDelete() bool {
+	return false
+}
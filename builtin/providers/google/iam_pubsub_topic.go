@@ -0,0 +1,102 @@
+package google
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/errwrap"
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/pubsub/v1"
+)
+
+var IamPubsubTopicSchema = map[string]*schema.Schema{
+	"topic": &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+
+	"project": &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+	},
+}
+
+type PubsubTopicIamUpdater struct {
+	topic  string
+	config *Config
+}
+
+func NewPubsubTopicIamUpdater(d *schema.ResourceData, config *Config) (ResourceIamUpdater, error) {
+	topic := d.Get("topic").(string)
+
+	// Accept either the short name or the full path, which is also what the
+	// google_pubsub_topic resource uses as its ID.
+	if !strings.HasPrefix(topic, "projects/") {
+		project, err := getProject(d, config)
+		if err != nil {
+			return nil, err
+		}
+		topic = fmt.Sprintf("projects/%s/topics/%s", project, topic)
+	}
+
+	parts := strings.Split(topic, "/")
+	if len(parts) != 4 || parts[2] != "topics" {
+		return nil, fmt.Errorf("Invalid topic %q, expected projects/{project}/topics/{name} or a topic name", topic)
+	}
+	d.Set("project", parts[1])
+
+	return &PubsubTopicIamUpdater{
+		topic:  topic,
+		config: config,
+	}, nil
+}
+
+func PubsubTopicIdParseFunc(d *schema.ResourceData, id string, _ *Config) error {
+	d.Set("topic", id)
+	return nil
+}
+
+func (u *PubsubTopicIamUpdater) GetResourceIamPolicy() (*cloudresourcemanager.Policy, error) {
+	p, err := u.config.clientPubsub.Projects.Topics.GetIamPolicy(u.topic).Do()
+	if err != nil {
+		return nil, errwrap.Wrapf(fmt.Sprintf("Error retrieving IAM policy for %s: {{err}}", u.DescribeResource()), err)
+	}
+
+	policy := &cloudresourcemanager.Policy{}
+	if err := convertIamPolicy(p, policy); err != nil {
+		return nil, fmt.Errorf("Error converting IAM policy for %s: %s", u.DescribeResource(), err)
+	}
+
+	return policy, nil
+}
+
+func (u *PubsubTopicIamUpdater) SetResourceIamPolicy(policy *cloudresourcemanager.Policy) error {
+	p := &pubsub.Policy{}
+	if err := convertIamPolicy(policy, p); err != nil {
+		return fmt.Errorf("Error converting IAM policy for %s: %s", u.DescribeResource(), err)
+	}
+
+	_, err := u.config.clientPubsub.Projects.Topics.SetIamPolicy(u.topic,
+		&pubsub.SetIamPolicyRequest{Policy: p}).Do()
+	if err != nil {
+		return errwrap.Wrapf(fmt.Sprintf("Error setting IAM policy for %s: {{err}}", u.DescribeResource()), err)
+	}
+
+	return nil
+}
+
+func (u *PubsubTopicIamUpdater) GetResourceId() string {
+	return u.topic
+}
+
+func (u *PubsubTopicIamUpdater) DescribeResource() string {
+	return fmt.Sprintf("pubsub topic %q", u.topic)
+}
+
+func (u *PubsubTopicIamUpdater) ClearPolicyOnDelete() bool {
+	return true
+}
@@ -0,0 +1,63 @@
+package google
+
+import (
+	"testing"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+func TestIamPolicyDataDiffSuppress(t *testing.T) {
+	cases := []struct {
+		Old, New string
+		Suppress bool
+	}{
+		{
+			Old:      `{"bindings":[{"role":"roles/viewer","members":["user:a@example.com","user:b@example.com"]}]}`,
+			New:      `{"bindings":[{"role":"roles/viewer","members":["user:b@example.com","user:a@example.com"]}]}`,
+			Suppress: true,
+		},
+		{
+			Old:      `{"bindings":[{"role":"roles/viewer","members":["user:a@example.com"]},{"role":"roles/editor","members":["user:b@example.com"]}]}`,
+			New:      `{"bindings":[{"role":"roles/editor","members":["user:b@example.com"]},{"role":"roles/viewer","members":["user:a@example.com"]}]}`,
+			Suppress: true,
+		},
+		{
+			Old:      `{"bindings":[{"role":"roles/viewer","members":["user:a@example.com"]}]}`,
+			New:      `{"bindings":[{"role":"roles/viewer","members":["user:b@example.com"]}]}`,
+			Suppress: false,
+		},
+		{
+			Old:      `{"bindings":[{"role":"roles/viewer","members":["user:a@example.com"]}]}`,
+			New:      `{"bindings":[{"role":"roles/editor","members":["user:a@example.com"]}]}`,
+			Suppress: false,
+		},
+		{
+			Old:      `{"bindings":[{"role":"roles/viewer","members":["user:a@example.com"]}]}`,
+			New:      `not json`,
+			Suppress: false,
+		},
+	}
+
+	for i, tc := range cases {
+		if v := iamPolicyDataDiffSuppress("policy_data", tc.Old, tc.New, nil); v != tc.Suppress {
+			t.Errorf("%d: expected suppress=%t, got %t", i, tc.Suppress, v)
+		}
+	}
+}
+
+func TestRemoveIamBinding(t *testing.T) {
+	bindings := []*cloudresourcemanager.Binding{
+		{Role: "roles/viewer", Members: []string{"user:a@example.com"}},
+		{Role: "roles/editor", Members: []string{"user:b@example.com"}},
+	}
+
+	rb := removeIamBinding(bindings, "roles/viewer")
+	if len(rb) != 1 || rb[0].Role != "roles/editor" {
+		t.Fatalf("expected only roles/editor to remain, got %#v", rb)
+	}
+
+	rb = removeIamBinding(bindings, "roles/owner")
+	if len(rb) != 2 {
+		t.Fatalf("expected bindings to be unchanged, got %#v", rb)
+	}
+}
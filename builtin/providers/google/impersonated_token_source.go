@@ -0,0 +1,119 @@
+package google
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jws"
+	"google.golang.org/api/iam/v1"
+)
+
+const googleTokenURL = "https://accounts.google.com/o/oauth2/token"
+
+// impersonatedTokenSource is an oauth2.TokenSource that mints access tokens
+// for a target service account without needing one of its keys. The IAM API
+// signs a JWT assertion as the target account on behalf of the caller, who
+// must be allowed to act as that account, and the signed assertion is then
+// exchanged for an access token just like a key-signed one would be.
+type impersonatedTokenSource struct {
+	iam      *iam.Service
+	email    string
+	scopes   []string
+	tokenURL string
+	client   *http.Client
+}
+
+// newImpersonatedTokenSource returns a token source for the service account
+// email. client authenticates the calls to the IAM API as the caller, and
+// tokenClient is used for the unauthenticated token exchange.
+func newImpersonatedTokenSource(client, tokenClient *http.Client, email string, scopes []string) (oauth2.TokenSource, error) {
+	iamService, err := iam.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := &impersonatedTokenSource{
+		iam:      iamService,
+		email:    email,
+		scopes:   scopes,
+		tokenURL: googleTokenURL,
+		client:   tokenClient,
+	}
+
+	return oauth2.ReuseTokenSource(nil, ts), nil
+}
+
+func (ts *impersonatedTokenSource) Token() (*oauth2.Token, error) {
+	claims := &jws.ClaimSet{
+		Iss:   ts.email,
+		Scope: strings.Join(ts.scopes, " "),
+		Aud:   ts.tokenURL,
+	}
+	header := &jws.Header{Algorithm: "RS256", Typ: "JWT"}
+
+	assertion, err := jws.EncodeWithSigner(header, claims, ts.sign)
+	if err != nil {
+		return nil, err
+	}
+
+	v := url.Values{}
+	v.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	v.Set("assertion", assertion)
+	resp, err := ts.client.PostForm(ts.tokenURL, v)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching token for service account %s: %s", ts.email, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching token for service account %s: %s", ts.email, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("Error fetching token for service account %s: %s\nResponse: %s",
+			ts.email, resp.Status, body)
+	}
+
+	var tokenRes struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tokenRes); err != nil {
+		return nil, fmt.Errorf("Error parsing token for service account %s: %s", ts.email, err)
+	}
+
+	token := &oauth2.Token{
+		AccessToken: tokenRes.AccessToken,
+		TokenType:   tokenRes.TokenType,
+	}
+	if tokenRes.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tokenRes.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// sign has the IAM API sign data with one of the target service account's
+// system-managed keys.
+func (ts *impersonatedTokenSource) sign(data []byte) ([]byte, error) {
+	name := fmt.Sprintf("projects/-/serviceAccounts/%s", ts.email)
+	req := &iam.SignBlobRequest{
+		BytesToSign: base64.StdEncoding.EncodeToString(data),
+	}
+
+	res, err := ts.iam.Projects.ServiceAccounts.SignBlob(name, req).Do()
+	if err != nil {
+		return nil, fmt.Errorf("Error impersonating service account %s: %s", ts.email, err)
+	}
+
+	return base64.StdEncoding.DecodeString(res.Signature)
+}
@@ -0,0 +1,77 @@
+package google
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2/jws"
+	"google.golang.org/api/iam/v1"
+)
+
+func TestImpersonatedTokenSource(t *testing.T) {
+	email := "deployer@my-project.iam.gserviceaccount.com"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/projects/-/serviceAccounts/"+email+":signBlob", func(w http.ResponseWriter, r *http.Request) {
+		var req iam.SignBlobRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("error decoding signBlob request: %s", err)
+		}
+		json.NewEncoder(w).Encode(&iam.SignBlobResponse{
+			KeyId:     "key",
+			Signature: base64.StdEncoding.EncodeToString([]byte("signature")),
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			t.Errorf("unexpected grant_type %q", r.FormValue("grant_type"))
+		}
+
+		assertion := r.FormValue("assertion")
+		claims, err := jws.Decode(assertion)
+		if err != nil {
+			t.Errorf("error decoding assertion: %s", err)
+		} else if claims.Iss != email {
+			t.Errorf("expected assertion to be issued by %s, got %s", email, claims.Iss)
+		}
+
+		parts := strings.Split(assertion, ".")
+		if sig := parts[len(parts)-1]; sig != base64.RawURLEncoding.EncodeToString([]byte("signature")) {
+			t.Errorf("expected assertion to carry the IAM signature, got %q", sig)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "impersonated", "token_type": "Bearer", "expires_in": 3600}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	iamService, err := iam.New(http.DefaultClient)
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	iamService.BasePath = ts.URL + "/"
+
+	src := &impersonatedTokenSource{
+		iam:      iamService,
+		email:    email,
+		scopes:   []string{"https://www.googleapis.com/auth/cloud-platform"},
+		tokenURL: ts.URL + "/token",
+		client:   http.DefaultClient,
+	}
+
+	token, err := src.Token()
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	if token.AccessToken != "impersonated" {
+		t.Fatalf("expected access token %q, got %q", "impersonated", token.AccessToken)
+	}
+	if !token.Valid() {
+		t.Fatalf("expected token to be valid, got expiry %s", token.Expiry)
+	}
+}
@@ -0,0 +1,55 @@
+package google
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// expandLabels returns the labels to send to the API for a resource: the
+// provider's default_labels, overridden by the resource's own labels field.
+func expandLabels(d *schema.ResourceData, config *Config) map[string]string {
+	labels := make(map[string]string)
+	for k, v := range config.DefaultLabels {
+		labels[k] = v
+	}
+	for k, v := range d.Get("labels").(map[string]interface{}) {
+		labels[k] = v.(string)
+	}
+
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// flattenLabels is the inverse of expandLabels. It drops the labels that
+// only come from default_labels and match them, so that they don't show up
+// as a diff against a labels field that doesn't mention them.
+//
+// A default label that is missing from the resource, e.g. because it was
+// added to default_labels after the resource was created, is kept with an
+// empty value instead. The labels field then differs from the config, and
+// the resulting update applies the default.
+func flattenLabels(d *schema.ResourceData, config *Config, labels map[string]string) map[string]string {
+	configured := d.Get("labels").(map[string]interface{})
+
+	result := make(map[string]string)
+	for k, v := range labels {
+		if _, ok := configured[k]; !ok {
+			if dv, ok := config.DefaultLabels[k]; ok && dv == v {
+				continue
+			}
+		}
+		result[k] = v
+	}
+
+	for k := range config.DefaultLabels {
+		if _, ok := configured[k]; ok {
+			continue
+		}
+		if _, ok := labels[k]; !ok {
+			result[k] = ""
+		}
+	}
+
+	return result
+}
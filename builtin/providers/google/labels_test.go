@@ -0,0 +1,71 @@
+package google
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestLabels(t *testing.T) {
+	config := &Config{
+		DefaultLabels: map[string]string{
+			"team": "data",
+			"env":  "dev",
+		},
+	}
+
+	d := resourceBigQueryDataset().Data(&terraform.InstanceState{
+		ID: "my-project:my_dataset",
+		Attributes: map[string]string{
+			"labels.%":   "2",
+			"labels.env": "prod",
+			"labels.app": "etl",
+		},
+	})
+
+	expanded := expandLabels(d, config)
+	expected := map[string]string{
+		"team": "data",
+		"env":  "prod",
+		"app":  "etl",
+	}
+	if !reflect.DeepEqual(expanded, expected) {
+		t.Fatalf("expected expanded labels %v, got %v", expected, expanded)
+	}
+
+	cases := []struct {
+		Remote   map[string]string
+		Expected map[string]string
+	}{
+		// Labels that only come from the defaults are hidden.
+		{expected, map[string]string{"env": "prod", "app": "etl"}},
+		// A default label changed outside of Terraform shows up as a diff.
+		{
+			map[string]string{"team": "web", "env": "prod", "app": "etl"},
+			map[string]string{"team": "web", "env": "prod", "app": "etl"},
+		},
+		// A configured label that happens to match a default is kept.
+		{
+			map[string]string{"team": "data", "env": "dev", "app": "etl"},
+			map[string]string{"env": "dev", "app": "etl"},
+		},
+		// A default label missing from the resource shows up as a diff.
+		{
+			map[string]string{"env": "prod", "app": "etl"},
+			map[string]string{"team": "", "env": "prod", "app": "etl"},
+		},
+	}
+
+	for i, tc := range cases {
+		flattened := flattenLabels(d, config, tc.Remote)
+		if !reflect.DeepEqual(flattened, tc.Expected) {
+			t.Fatalf("%d: expected flattened labels %v, got %v", i, tc.Expected, flattened)
+		}
+	}
+
+	empty := resourceBigQueryDataset().Data(nil)
+	if labels := expandLabels(empty, &Config{}); labels != nil {
+		t.Fatalf("expected no labels, got %v", labels)
+	}
+}
@@ -0,0 +1,92 @@
+package google
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/container/v1"
+)
+
+// schemaNodeConfig is the node_config block shared by google_container_cluster,
+// for its default node pool, and google_container_node_pool.
+var schemaNodeConfig = &schema.Schema{
+	Type:     schema.TypeList,
+	Optional: true,
+	Computed: true,
+	ForceNew: true,
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"machine_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"disk_size_gb": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(int)
+
+					if value < 10 {
+						errors = append(errors, fmt.Errorf(
+							"%q cannot be less than 10", k))
+					}
+					return
+				},
+			},
+
+			"oauth_scopes": &schema.Schema{
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	},
+}
+
+func expandNodeConfig(v interface{}) *container.NodeConfig {
+	nodeConfig := v.(map[string]interface{})
+
+	nc := &container.NodeConfig{}
+
+	if v, ok := nodeConfig["machine_type"]; ok {
+		nc.MachineType = v.(string)
+	}
+
+	if v, ok := nodeConfig["disk_size_gb"]; ok {
+		nc.DiskSizeGb = int64(v.(int))
+	}
+
+	if v, ok := nodeConfig["oauth_scopes"]; ok {
+		scopesList := v.([]interface{})
+		scopes := []string{}
+		for _, v := range scopesList {
+			scopes = append(scopes, v.(string))
+		}
+
+		nc.OauthScopes = scopes
+	}
+
+	return nc
+}
+
+func flattenClusterNodeConfig(c *container.NodeConfig) []map[string]interface{} {
+	config := []map[string]interface{}{
+		map[string]interface{}{
+			"machine_type": c.MachineType,
+			"disk_size_gb": c.DiskSizeGb,
+		},
+	}
+
+	if len(c.OauthScopes) > 0 {
+		config[0]["oauth_scopes"] = c.OauthScopes
+	}
+
+	return config
+}
@@ -3,7 +3,9 @@ package google
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/pathorcontents"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -35,6 +37,18 @@ func Provider() terraform.ResourceProvider {
 				ValidateFunc: validateCredentials,
 			},
 
+			"access_token": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GOOGLE_OAUTH_ACCESS_TOKEN", nil),
+			},
+
+			"impersonate_service_account": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GOOGLE_IMPERSONATE_SERVICE_ACCOUNT", nil),
+			},
+
 			"project": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -54,13 +68,44 @@ func Provider() terraform.ResourceProvider {
 					"CLOUDSDK_COMPUTE_REGION",
 				}, nil),
 			},
+
+			"max_retries": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  5,
+			},
+
+			"request_timeout": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+			},
+
+			"default_labels": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     schema.TypeString,
+			},
+
+			"bigquery_custom_endpoint":         customEndpointSchema("GOOGLE_BIGQUERY_CUSTOM_ENDPOINT"),
+			"compute_custom_endpoint":          customEndpointSchema("GOOGLE_COMPUTE_CUSTOM_ENDPOINT"),
+			"container_custom_endpoint":        customEndpointSchema("GOOGLE_CONTAINER_CUSTOM_ENDPOINT"),
+			"dataflow_custom_endpoint":         customEndpointSchema("GOOGLE_DATAFLOW_CUSTOM_ENDPOINT"),
+			"dns_custom_endpoint":              customEndpointSchema("GOOGLE_DNS_CUSTOM_ENDPOINT"),
+			"pubsub_custom_endpoint":           customEndpointSchema("GOOGLE_PUBSUB_CUSTOM_ENDPOINT"),
+			"resource_manager_custom_endpoint": customEndpointSchema("GOOGLE_RESOURCE_MANAGER_CUSTOM_ENDPOINT"),
+			"sql_custom_endpoint":              customEndpointSchema("GOOGLE_SQL_CUSTOM_ENDPOINT"),
+			"storage_custom_endpoint":          customEndpointSchema("GOOGLE_STORAGE_CUSTOM_ENDPOINT"),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"google_iam_policy": dataSourceGoogleIamPolicy(),
+			"google_dataflow_job": dataSourceGoogleDataflowJob(),
+			"google_iam_policy":   dataSourceGoogleIamPolicy(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"google_bigquery_dataset":               resourceBigQueryDataset(),
+			"google_bigquery_table":                 resourceBigQueryTable(),
 			"google_compute_autoscaler":             resourceComputeAutoscaler(),
 			"google_compute_address":                resourceComputeAddress(),
 			"google_compute_backend_service":        resourceComputeBackendService(),
@@ -88,13 +133,20 @@ func Provider() terraform.ResourceProvider {
 			"google_compute_vpn_gateway":            resourceComputeVpnGateway(),
 			"google_compute_vpn_tunnel":             resourceComputeVpnTunnel(),
 			"google_container_cluster":              resourceContainerCluster(),
+			"google_container_node_pool":            resourceContainerNodePool(),
 			"google_dns_managed_zone":               resourceDnsManagedZone(),
 			"google_dns_record_set":                 resourceDnsRecordSet(),
 			"google_sql_database":                   resourceSqlDatabase(),
 			"google_sql_database_instance":          resourceSqlDatabaseInstance(),
 			"google_sql_user":                       resourceSqlUser(),
 			"google_project":                        resourceGoogleProject(),
+			"google_project_iam_policy":             ResourceIamPolicy(IamProjectSchema, NewProjectIamUpdater, ProjectIdParseFunc),
+			"google_project_iam_binding":            ResourceIamBinding(IamProjectSchema, NewProjectIamUpdater, ProjectIdParseFunc),
+			"google_project_iam_member":             ResourceIamMember(IamProjectSchema, NewProjectIamUpdater, ProjectIdParseFunc),
 			"google_pubsub_topic":                   resourcePubsubTopic(),
+			"google_pubsub_topic_iam_policy":        ResourceIamPolicy(IamPubsubTopicSchema, NewPubsubTopicIamUpdater, PubsubTopicIdParseFunc),
+			"google_pubsub_topic_iam_binding":       ResourceIamBinding(IamPubsubTopicSchema, NewPubsubTopicIamUpdater, PubsubTopicIdParseFunc),
+			"google_pubsub_topic_iam_member":        ResourceIamMember(IamPubsubTopicSchema, NewPubsubTopicIamUpdater, PubsubTopicIdParseFunc),
 			"google_pubsub_subscription":            resourcePubsubSubscription(),
 			"google_storage_bucket":                 resourceStorageBucket(),
 			"google_storage_bucket_acl":             resourceStorageBucketAcl(),
@@ -113,8 +165,34 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	}
 	config := Config{
 		Credentials: credentials,
+		AccessToken: d.Get("access_token").(string),
 		Project:     d.Get("project").(string),
 		Region:      d.Get("region").(string),
+		MaxRetries:  d.Get("max_retries").(int),
+
+		ImpersonateServiceAccount: d.Get("impersonate_service_account").(string),
+
+		BigQueryEndpoint:        getCustomEndpoint(d, "bigquery_custom_endpoint"),
+		ComputeEndpoint:         getCustomEndpoint(d, "compute_custom_endpoint"),
+		ContainerEndpoint:       getCustomEndpoint(d, "container_custom_endpoint"),
+		DataflowEndpoint:        getCustomEndpoint(d, "dataflow_custom_endpoint"),
+		DnsEndpoint:             getCustomEndpoint(d, "dns_custom_endpoint"),
+		PubsubEndpoint:          getCustomEndpoint(d, "pubsub_custom_endpoint"),
+		ResourceManagerEndpoint: getCustomEndpoint(d, "resource_manager_custom_endpoint"),
+		SqlAdminEndpoint:        getCustomEndpoint(d, "sql_custom_endpoint"),
+		StorageEndpoint:         getCustomEndpoint(d, "storage_custom_endpoint"),
+	}
+
+	if v, ok := d.GetOk("default_labels"); ok {
+		config.DefaultLabels = make(map[string]string)
+		for k, v := range v.(map[string]interface{}) {
+			config.DefaultLabels[k] = v.(string)
+		}
+	}
+
+	if v, ok := d.GetOk("request_timeout"); ok {
+		// Already checked by validateDuration.
+		config.RequestTimeout, _ = time.ParseDuration(v.(string))
 	}
 
 	if err := config.loadAndValidate(); err != nil {
@@ -168,6 +246,47 @@ func validateCredentials(v interface{}, k string) (warnings []string, errors []e
 	return
 }
 
+func validateDuration(v interface{}, k string) (warnings []string, errors []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+	}
+
+	return
+}
+
+func validateCustomEndpoint(v interface{}, k string) (warnings []string, errors []error) {
+	u, err := url.Parse(v.(string))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be an absolute http or https URL, got %q", k, v.(string)))
+	}
+
+	return
+}
+
+// customEndpointSchema returns the schema for a field overriding the base
+// URL of one of the API clients, defaulting to the given environment
+// variable.
+func customEndpointSchema(envVar string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		DefaultFunc:  schema.EnvDefaultFunc(envVar, nil),
+		ValidateFunc: validateCustomEndpoint,
+	}
+}
+
+// getCustomEndpoint reads a custom endpoint field, making sure it ends in a
+// slash so that the API clients resolve their request paths below it rather
+// than replacing its last path segment.
+func getCustomEndpoint(d *schema.ResourceData, field string) string {
+	endpoint := d.Get(field).(string)
+	if endpoint != "" && !strings.HasSuffix(endpoint, "/") {
+		endpoint += "/"
+	}
+	return endpoint
+}
+
 // getRegionFromZone returns the region from a zone for Google cloud.
 func getRegionFromZone(zone string) string {
 	if zone != "" && len(zone) > 2 {
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -30,6 +31,21 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestProvider_validateWithoutCustomEndpoints(t *testing.T) {
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"project": "my-project",
+		"region":  "us-central1",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ws, es := Provider().Validate(terraform.NewResourceConfig(raw))
+	if len(ws) > 0 || len(es) > 0 {
+		t.Fatalf("expected no warnings or errors, got warnings: %v, errors: %v", ws, es)
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("GOOGLE_CREDENTIALS_FILE"); v != "" {
 		creds, err := ioutil.ReadFile(v)
@@ -83,3 +99,20 @@ func TestProvider_getRegionFromZone(t *testing.T) {
 		t.Fatalf("Region (%s) did not match expected value: %s", actual, expected)
 	}
 }
+
+func TestProvider_validateCustomEndpoint(t *testing.T) {
+	cases := map[string]bool{
+		"https://www.googleapis.com/dataflow/": true,
+		"http://localhost:8085/":               true,
+		"localhost:8085":                       false,
+		"/dataflow/":                           false,
+		"ftp://example.com/":                   false,
+	}
+
+	for endpoint, valid := range cases {
+		_, errors := validateCustomEndpoint(endpoint, "dataflow_custom_endpoint")
+		if valid != (len(errors) == 0) {
+			t.Errorf("endpoint %q: expected valid=%t, got errors: %v", endpoint, valid, errors)
+		}
+	}
+}
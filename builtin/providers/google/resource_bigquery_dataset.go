@@ -0,0 +1,343 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceBigQueryDataset() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigQueryDatasetCreate,
+		Read:   resourceBigQueryDatasetRead,
+		Update: resourceBigQueryDatasetUpdate,
+		Delete: resourceBigQueryDatasetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"dataset_id": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateBigQueryDatasetId,
+			},
+
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"friendly_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"location": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "US",
+			},
+
+			"default_table_expiration_ms": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					value := v.(int)
+					if value < 3600000 {
+						errors = append(errors, fmt.Errorf("%q cannot be shorter than 3600000 milliseconds (one hour)", k))
+					}
+					return
+				},
+			},
+
+			"labels": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     schema.TypeString,
+			},
+
+			// BigQuery grants a default set of permissions to new datasets,
+			// so when access is left out the ones the API picked are kept.
+			"access": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateAllowedStringValue([]string{"READER", "WRITER", "OWNER"}),
+						},
+
+						"domain": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"group_by_email": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"special_group": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ValidateFunc: validateAllowedStringValue([]string{
+								"projectOwners", "projectReaders", "projectWriters", "allAuthenticatedUsers",
+							}),
+						},
+
+						"user_by_email": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"view": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"project_id": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"dataset_id": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+
+									"table_id": &schema.Schema{
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"self_link": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_time": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"last_modified_time": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func validateBigQueryDatasetId(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(`^[0-9A-Za-z_]+$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must contain only letters (a-z, A-Z), numbers (0-9), or underscores (_)", k))
+	}
+	if len(value) > 1024 {
+		errors = append(errors, fmt.Errorf(
+			"%q cannot be greater than 1,024 characters", k))
+	}
+	return
+}
+
+func resourceDataset(d *schema.ResourceData, config *Config, project string) *bigquery.Dataset {
+	dataset := &bigquery.Dataset{
+		DatasetReference: &bigquery.DatasetReference{
+			ProjectId: project,
+			DatasetId: d.Get("dataset_id").(string),
+		},
+		FriendlyName:             d.Get("friendly_name").(string),
+		Description:              d.Get("description").(string),
+		Location:                 d.Get("location").(string),
+		DefaultTableExpirationMs: int64(d.Get("default_table_expiration_ms").(int)),
+	}
+
+	dataset.Labels = expandLabels(d, config)
+
+	for _, raw := range d.Get("access").(*schema.Set).List() {
+		data := raw.(map[string]interface{})
+		access := &bigquery.DatasetAccess{
+			Role:         data["role"].(string),
+			Domain:       data["domain"].(string),
+			GroupByEmail: data["group_by_email"].(string),
+			SpecialGroup: data["special_group"].(string),
+			UserByEmail:  data["user_by_email"].(string),
+		}
+		if views := data["view"].([]interface{}); len(views) > 0 {
+			view := views[0].(map[string]interface{})
+			access.View = &bigquery.TableReference{
+				ProjectId: view["project_id"].(string),
+				DatasetId: view["dataset_id"].(string),
+				TableId:   view["table_id"].(string),
+			}
+		}
+		dataset.Access = append(dataset.Access, access)
+	}
+
+	return dataset
+}
+
+func resourceBigQueryDatasetCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	dataset := resourceDataset(d, config, project)
+
+	log.Printf("[INFO] Creating BigQuery dataset: %s", dataset.DatasetReference.DatasetId)
+
+	res, err := config.clientBigQuery.Datasets.Insert(project, dataset).Do()
+	if err != nil {
+		return fmt.Errorf("Error creating BigQuery dataset: %s", err)
+	}
+
+	log.Printf("[INFO] BigQuery dataset %s has been created", res.Id)
+
+	d.SetId(res.Id)
+
+	return resourceBigQueryDatasetRead(d, meta)
+}
+
+// parseBigQueryDatasetId splits a dataset ID of the form project:dataset_id,
+// which is how the API identifies datasets. Domain-scoped project IDs such as
+// example.com:my-project contain a colon too, so split on the last one.
+func parseBigQueryDatasetId(id string) (string, string, error) {
+	i := strings.LastIndex(id, ":")
+	if i <= 0 || i == len(id)-1 {
+		return "", "", fmt.Errorf("Invalid BigQuery dataset ID %q, expected {project}:{dataset_id}", id)
+	}
+	return id[:i], id[i+1:], nil
+}
+
+func resourceBigQueryDatasetRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, datasetId, err := parseBigQueryDatasetId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	res, err := config.clientBigQuery.Datasets.Get(project, datasetId).Do()
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing BigQuery dataset %q because it's gone", d.Id())
+			// The resource doesn't exist anymore
+			d.SetId("")
+
+			return nil
+		}
+
+		return fmt.Errorf("Error reading BigQuery dataset %s: %s", d.Id(), err)
+	}
+
+	d.Set("dataset_id", res.DatasetReference.DatasetId)
+	d.Set("project", res.DatasetReference.ProjectId)
+	d.Set("friendly_name", res.FriendlyName)
+	d.Set("description", res.Description)
+	d.Set("location", res.Location)
+	d.Set("default_table_expiration_ms", res.DefaultTableExpirationMs)
+	d.Set("labels", flattenLabels(d, config, res.Labels))
+	d.Set("self_link", res.SelfLink)
+	d.Set("etag", res.Etag)
+	d.Set("creation_time", res.CreationTime)
+	d.Set("last_modified_time", res.LastModifiedTime)
+
+	access := make([]map[string]interface{}, 0, len(res.Access))
+	for _, a := range res.Access {
+		data := map[string]interface{}{
+			"role":           a.Role,
+			"domain":         a.Domain,
+			"group_by_email": a.GroupByEmail,
+			"special_group":  a.SpecialGroup,
+			"user_by_email":  a.UserByEmail,
+		}
+		if a.View != nil {
+			data["view"] = []map[string]interface{}{
+				{
+					"project_id": a.View.ProjectId,
+					"dataset_id": a.View.DatasetId,
+					"table_id":   a.View.TableId,
+				},
+			}
+		}
+		access = append(access, data)
+	}
+	if err := d.Set("access", access); err != nil {
+		return fmt.Errorf("Error setting access for BigQuery dataset %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceBigQueryDatasetUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, datasetId, err := parseBigQueryDatasetId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	dataset := resourceDataset(d, config, project)
+
+	// Update replaces the whole dataset, so fields that were unset in the
+	// config, such as the expiration or labels, are cleared as expected.
+	log.Printf("[INFO] Updating BigQuery dataset: %s", d.Id())
+
+	if _, err := config.clientBigQuery.Datasets.Update(project, datasetId, dataset).Do(); err != nil {
+		return fmt.Errorf("Error updating BigQuery dataset %s: %s", d.Id(), err)
+	}
+
+	return resourceBigQueryDatasetRead(d, meta)
+}
+
+func resourceBigQueryDatasetDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, datasetId, err := parseBigQueryDatasetId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting BigQuery dataset: %s", d.Id())
+
+	if err := config.clientBigQuery.Datasets.Delete(project, datasetId).Do(); err != nil {
+		return fmt.Errorf("Error deleting BigQuery dataset %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
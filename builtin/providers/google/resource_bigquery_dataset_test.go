@@ -0,0 +1,161 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBigQueryDataset_basic(t *testing.T) {
+	datasetID := fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBigQueryDatasetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBigQueryDataset(datasetID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBigQueryDatasetExists(
+						"google_bigquery_dataset.test"),
+					resource.TestCheckResourceAttr(
+						"google_bigquery_dataset.test", "labels.env", "foo"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccBigQueryDatasetUpdated(datasetID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBigQueryDatasetExists(
+						"google_bigquery_dataset.test"),
+					resource.TestCheckResourceAttr(
+						"google_bigquery_dataset.test", "friendly_name", "bar"),
+					resource.TestCheckResourceAttr(
+						"google_bigquery_dataset.test", "labels.env", "bar"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseBigQueryDatasetId(t *testing.T) {
+	cases := []struct {
+		Id        string
+		Project   string
+		DatasetId string
+		Err       bool
+	}{
+		{"my-project:my_dataset", "my-project", "my_dataset", false},
+		{"example.com:my-project:my_dataset", "example.com:my-project", "my_dataset", false},
+		{"my_dataset", "", "", true},
+		{":my_dataset", "", "", true},
+		{"my-project:", "", "", true},
+	}
+
+	for _, tc := range cases {
+		project, datasetId, err := parseBigQueryDatasetId(tc.Id)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%s: expected error %t, got %v", tc.Id, tc.Err, err)
+		}
+		if project != tc.Project || datasetId != tc.DatasetId {
+			t.Fatalf("%s: expected %q, %q, got %q, %q", tc.Id, tc.Project, tc.DatasetId, project, datasetId)
+		}
+	}
+}
+
+func testAccCheckBigQueryDatasetDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_bigquery_dataset" {
+			continue
+		}
+
+		project, datasetId, err := parseBigQueryDatasetId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = config.clientBigQuery.Datasets.Get(project, datasetId).Do()
+		if err == nil {
+			return fmt.Errorf("BigQuery dataset still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckBigQueryDatasetExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+
+		project, datasetId, err := parseBigQueryDatasetId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		found, err := config.clientBigQuery.Datasets.Get(project, datasetId).Do()
+		if err != nil {
+			return err
+		}
+
+		if found.Id != rs.Primary.ID {
+			return fmt.Errorf("BigQuery dataset not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccBigQueryDataset(datasetID string) string {
+	return fmt.Sprintf(`
+resource "google_bigquery_dataset" "test" {
+	dataset_id = "%s"
+	friendly_name = "foo"
+	description = "This is a foo description"
+	location = "EU"
+	default_table_expiration_ms = 3600000
+
+	labels {
+		env = "foo"
+	}
+}`, datasetID)
+}
+
+func testAccBigQueryDatasetUpdated(datasetID string) string {
+	return fmt.Sprintf(`
+resource "google_bigquery_dataset" "test" {
+	dataset_id = "%s"
+	friendly_name = "bar"
+	description = "This is a bar description"
+	location = "EU"
+	default_table_expiration_ms = 7200000
+
+	labels {
+		env = "bar"
+	}
+
+	access {
+		role = "OWNER"
+		special_group = "projectOwners"
+	}
+
+	access {
+		role = "READER"
+		domain = "example.com"
+	}
+}`, datasetID)
+}
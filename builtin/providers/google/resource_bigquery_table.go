@@ -0,0 +1,398 @@
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceBigQueryTable() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigQueryTableCreate,
+		Read:   resourceBigQueryTableRead,
+		Update: resourceBigQueryTableUpdate,
+		Delete: resourceBigQueryTableDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"table_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"dataset_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"friendly_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Milliseconds since the epoch. Tables in a dataset with a
+			// default expiration get one even when this is left out.
+			"expiration_time": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			// The schema is given as the JSON array of fields accepted by
+			// the bq command line tool and the API. For views it is derived
+			// from the query.
+			"schema": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateBigQueryTableSchema,
+				StateFunc: func(v interface{}) string {
+					normalized, _ := normalizeBigQueryTableSchema(v.(string))
+					return normalized
+				},
+			},
+
+			// BigQuery can't add or drop partitioning on an existing table,
+			// so adding or removing the block recreates it.
+			"time_partitioning": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validateAllowedStringValue([]string{"DAY"}),
+						},
+
+						"expiration_ms": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"view": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"query": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"use_legacy_sql": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+
+			"type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"location": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"num_bytes": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"num_long_term_bytes": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"num_rows": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"self_link": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"etag": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_time": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"last_modified_time": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandBigQueryTableSchema(raw string) (*bigquery.TableSchema, error) {
+	var fields []*bigquery.TableFieldSchema
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return nil, err
+	}
+	return &bigquery.TableSchema{Fields: fields}, nil
+}
+
+func flattenBigQueryTableSchema(s *bigquery.TableSchema) (string, error) {
+	if s == nil || len(s.Fields) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(s.Fields)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// normalizeBigQueryTableSchema rewrites a schema in the canonical form that
+// flattenBigQueryTableSchema produces, so formatting differences between the
+// config and the API don't show up as diffs.
+func normalizeBigQueryTableSchema(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+	s, err := expandBigQueryTableSchema(raw)
+	if err != nil {
+		return "", err
+	}
+	return flattenBigQueryTableSchema(s)
+}
+
+func validateBigQueryTableSchema(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := normalizeBigQueryTableSchema(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a JSON array of fields: %s", k, err))
+	}
+	return
+}
+
+func resourceTable(d *schema.ResourceData, project string) (*bigquery.Table, error) {
+	table := &bigquery.Table{
+		TableReference: &bigquery.TableReference{
+			ProjectId: project,
+			DatasetId: d.Get("dataset_id").(string),
+			TableId:   d.Get("table_id").(string),
+		},
+		FriendlyName:   d.Get("friendly_name").(string),
+		Description:    d.Get("description").(string),
+		ExpirationTime: int64(d.Get("expiration_time").(int)),
+	}
+
+	if v, ok := d.GetOk("view"); ok {
+		view := v.([]interface{})[0].(map[string]interface{})
+		table.View = &bigquery.ViewDefinition{
+			Query:        view["query"].(string),
+			UseLegacySql: view["use_legacy_sql"].(bool),
+		}
+		// The API treats a missing useLegacySql as true, so false has to
+		// be sent explicitly.
+		table.View.ForceSendFields = []string{"UseLegacySql"}
+	} else if v, ok := d.GetOk("schema"); ok {
+		// Only set the schema of regular tables; the schema of a view is
+		// derived from its query and sending it back is rejected.
+		tableSchema, err := expandBigQueryTableSchema(v.(string))
+		if err != nil {
+			return nil, err
+		}
+		table.Schema = tableSchema
+	}
+
+	if v, ok := d.GetOk("time_partitioning"); ok {
+		tp := v.([]interface{})[0].(map[string]interface{})
+		table.TimePartitioning = &bigquery.TimePartitioning{
+			Type:         tp["type"].(string),
+			ExpirationMs: int64(tp["expiration_ms"].(int)),
+		}
+	}
+
+	return table, nil
+}
+
+func resourceBigQueryTableCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	table, err := resourceTable(d, project)
+	if err != nil {
+		return err
+	}
+
+	datasetId := d.Get("dataset_id").(string)
+
+	log.Printf("[INFO] Creating BigQuery table: %s", table.TableReference.TableId)
+
+	res, err := config.clientBigQuery.Tables.Insert(project, datasetId, table).Do()
+	if err != nil {
+		return fmt.Errorf("Error creating BigQuery table: %s", err)
+	}
+
+	log.Printf("[INFO] BigQuery table %s has been created", res.Id)
+
+	d.SetId(res.Id)
+
+	return resourceBigQueryTableRead(d, meta)
+}
+
+// parseBigQueryTableId splits a table ID of the form
+// project:dataset_id.table_id, which is how the API identifies tables.
+func parseBigQueryTableId(id string) (string, string, string, error) {
+	project, rest, err := parseBigQueryDatasetId(id)
+	if err == nil {
+		parts := strings.SplitN(rest, ".", 2)
+		if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
+			return project, parts[0], parts[1], nil
+		}
+	}
+	return "", "", "", fmt.Errorf("Invalid BigQuery table ID %q, expected {project}:{dataset_id}.{table_id}", id)
+}
+
+func resourceBigQueryTableRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, datasetId, tableId, err := parseBigQueryTableId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	res, err := config.clientBigQuery.Tables.Get(project, datasetId, tableId).Do()
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing BigQuery table %q because it's gone", d.Id())
+			// The resource doesn't exist anymore
+			d.SetId("")
+
+			return nil
+		}
+
+		return fmt.Errorf("Error reading BigQuery table %s: %s", d.Id(), err)
+	}
+
+	d.Set("table_id", res.TableReference.TableId)
+	d.Set("dataset_id", res.TableReference.DatasetId)
+	d.Set("project", res.TableReference.ProjectId)
+	d.Set("friendly_name", res.FriendlyName)
+	d.Set("description", res.Description)
+	d.Set("expiration_time", res.ExpirationTime)
+	d.Set("type", res.Type)
+	d.Set("location", res.Location)
+	d.Set("num_bytes", res.NumBytes)
+	d.Set("num_long_term_bytes", res.NumLongTermBytes)
+	d.Set("num_rows", int64(res.NumRows))
+	d.Set("self_link", res.SelfLink)
+	d.Set("etag", res.Etag)
+	d.Set("creation_time", res.CreationTime)
+	d.Set("last_modified_time", int64(res.LastModifiedTime))
+
+	tableSchema, err := flattenBigQueryTableSchema(res.Schema)
+	if err != nil {
+		return fmt.Errorf("Error flattening schema of BigQuery table %s: %s", d.Id(), err)
+	}
+	d.Set("schema", tableSchema)
+
+	if res.TimePartitioning != nil {
+		d.Set("time_partitioning", []map[string]interface{}{
+			{
+				"type":          res.TimePartitioning.Type,
+				"expiration_ms": res.TimePartitioning.ExpirationMs,
+			},
+		})
+	} else {
+		d.Set("time_partitioning", nil)
+	}
+
+	if res.View != nil {
+		d.Set("view", []map[string]interface{}{
+			{
+				"query":          res.View.Query,
+				"use_legacy_sql": res.View.UseLegacySql,
+			},
+		})
+	} else {
+		d.Set("view", nil)
+	}
+
+	return nil
+}
+
+func resourceBigQueryTableUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, datasetId, tableId, err := parseBigQueryTableId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	table, err := resourceTable(d, project)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Updating BigQuery table: %s", d.Id())
+
+	if _, err := config.clientBigQuery.Tables.Update(project, datasetId, tableId, table).Do(); err != nil {
+		return fmt.Errorf("Error updating BigQuery table %s: %s", d.Id(), err)
+	}
+
+	return resourceBigQueryTableRead(d, meta)
+}
+
+func resourceBigQueryTableDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, datasetId, tableId, err := parseBigQueryTableId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting BigQuery table: %s", d.Id())
+
+	if err := config.clientBigQuery.Tables.Delete(project, datasetId, tableId).Do(); err != nil {
+		return fmt.Errorf("Error deleting BigQuery table %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
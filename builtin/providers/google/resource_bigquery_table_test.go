@@ -0,0 +1,253 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBigQueryTable_basic(t *testing.T) {
+	datasetID := fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	tableID := fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBigQueryTableDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBigQueryTable(datasetID, tableID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBigQueryTableExists(
+						"google_bigquery_table.test"),
+					resource.TestCheckResourceAttr(
+						"google_bigquery_table.test", "time_partitioning.0.type", "DAY"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccBigQueryTableUpdated(datasetID, tableID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBigQueryTableExists(
+						"google_bigquery_table.test"),
+					resource.TestCheckResourceAttr(
+						"google_bigquery_table.test", "description", "updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBigQueryTable_view(t *testing.T) {
+	datasetID := fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+	tableID := fmt.Sprintf("tf_test_%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBigQueryTableDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBigQueryTableView(datasetID, tableID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccBigQueryTableExists(
+						"google_bigquery_table.view"),
+					resource.TestCheckResourceAttr(
+						"google_bigquery_table.view", "type", "VIEW"),
+				),
+			},
+		},
+	})
+}
+
+func TestNormalizeBigQueryTableSchema(t *testing.T) {
+	cases := []struct {
+		In  string
+		Out string
+		Err bool
+	}{
+		{"", "", false},
+		{
+			`[ { "name": "city",  "type": "STRING" } ]`,
+			`[{"name":"city","type":"STRING"}]`,
+			false,
+		},
+		{
+			`[{"type": "RECORD", "name": "a", "fields": [{"name": "b", "type": "INTEGER", "mode": "REPEATED"}]}]`,
+			`[{"fields":[{"mode":"REPEATED","name":"b","type":"INTEGER"}],"name":"a","type":"RECORD"}]`,
+			false,
+		},
+		{`{"name": "city"}`, "", true},
+		{`not json`, "", true},
+	}
+
+	for _, tc := range cases {
+		out, err := normalizeBigQueryTableSchema(tc.In)
+		if (err != nil) != tc.Err {
+			t.Fatalf("%s: expected error %t, got %v", tc.In, tc.Err, err)
+		}
+		if out != tc.Out {
+			t.Fatalf("%s: expected %s, got %s", tc.In, tc.Out, out)
+		}
+	}
+}
+
+func testAccCheckBigQueryTableDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_bigquery_table" {
+			continue
+		}
+
+		project, datasetId, tableId, err := parseBigQueryTableId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = config.clientBigQuery.Tables.Get(project, datasetId, tableId).Do()
+		if err == nil {
+			return fmt.Errorf("BigQuery table still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccBigQueryTableExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+
+		project, datasetId, tableId, err := parseBigQueryTableId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		found, err := config.clientBigQuery.Tables.Get(project, datasetId, tableId).Do()
+		if err != nil {
+			return err
+		}
+
+		if found.Id != rs.Primary.ID {
+			return fmt.Errorf("BigQuery table not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccBigQueryTable(datasetID, tableID string) string {
+	return fmt.Sprintf(`
+resource "google_bigquery_dataset" "test" {
+	dataset_id = "%s"
+}
+
+resource "google_bigquery_table" "test" {
+	table_id = "%s"
+	dataset_id = "${google_bigquery_dataset.test.dataset_id}"
+
+	time_partitioning {
+		type = "DAY"
+	}
+
+	schema = <<EOH
+[
+  {
+    "name": "city",
+    "type": "RECORD",
+    "fields": [
+      {
+        "name": "id",
+        "type": "INTEGER"
+      },
+      {
+        "name": "coord",
+        "type": "RECORD",
+        "fields": [
+          {
+            "name": "lon",
+            "type": "FLOAT"
+          }
+        ]
+      }
+    ]
+  }
+]
+EOH
+}`, datasetID, tableID)
+}
+
+func testAccBigQueryTableUpdated(datasetID, tableID string) string {
+	return fmt.Sprintf(`
+resource "google_bigquery_dataset" "test" {
+	dataset_id = "%s"
+}
+
+resource "google_bigquery_table" "test" {
+	table_id = "%s"
+	dataset_id = "${google_bigquery_dataset.test.dataset_id}"
+	description = "updated"
+
+	time_partitioning {
+		type = "DAY"
+		expiration_ms = 86400000
+	}
+
+	schema = <<EOH
+[
+  {
+    "name": "city",
+    "type": "RECORD",
+    "fields": [
+      {
+        "name": "id",
+        "type": "INTEGER"
+      },
+      {
+        "name": "coord",
+        "type": "RECORD",
+        "fields": [
+          {
+            "name": "lon",
+            "type": "FLOAT"
+          },
+          {
+            "name": "lat",
+            "type": "FLOAT"
+          }
+        ]
+      }
+    ]
+  }
+]
+EOH
+}`, datasetID, tableID)
+}
+
+func testAccBigQueryTableView(datasetID, tableID string) string {
+	return fmt.Sprintf(`
+resource "google_bigquery_dataset" "test" {
+	dataset_id = "%s"
+}
+
+resource "google_bigquery_table" "view" {
+	table_id = "%s"
+	dataset_id = "${google_bigquery_dataset.test.dataset_id}"
+
+	view {
+		query = "SELECT state FROM [lookerdata:cdc.project_tycho_reports]"
+	}
+}`, datasetID, tableID)
+}
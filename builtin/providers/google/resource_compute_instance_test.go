@@ -2,6 +2,7 @@ package google
 
 import (
 	"fmt"
+	"log"
 	"strings"
 	"testing"
 
@@ -11,6 +12,64 @@ import (
 	"google.golang.org/api/compute/v1"
 )
 
+func init() {
+	resource.AddTestSweepers("gcp_compute_instance", &resource.Sweeper{
+		Name: "gcp_compute_instance",
+		F:    testSweepComputeInstances,
+	})
+}
+
+func testSweepComputeInstances(region string) error {
+	config, err := sharedConfigForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	zoneList, err := config.clientCompute.Zones.List(config.Project).Do()
+	if err != nil {
+		return fmt.Errorf("Error listing zones: %s", err)
+	}
+
+	for _, zone := range zoneList.Items {
+		if zone.Region != region && !strings.HasSuffix(zone.Region, "/"+region) {
+			continue
+		}
+
+		pageToken := ""
+		for {
+			call := config.clientCompute.Instances.List(config.Project, zone.Name).
+				Filter("name eq instance-test-.*")
+			if pageToken != "" {
+				call = call.PageToken(pageToken)
+			}
+			instances, err := call.Do()
+			if err != nil {
+				return fmt.Errorf("Error listing instances in zone %s: %s", zone.Name, err)
+			}
+
+			for _, instance := range instances.Items {
+				log.Printf("[INFO] Sweeping instance %s in zone %s", instance.Name, zone.Name)
+				op, err := config.clientCompute.Instances.Delete(config.Project, zone.Name, instance.Name).Do()
+				if err != nil {
+					return fmt.Errorf("Error deleting instance %s: %s", instance.Name, err)
+				}
+
+				err = computeOperationWaitZone(config, op, config.Project, zone.Name, "Deleting instance")
+				if err != nil {
+					return err
+				}
+			}
+
+			if instances.NextPageToken == "" {
+				break
+			}
+			pageToken = instances.NextPageToken
+		}
+	}
+
+	return nil
+}
+
 func TestAccComputeInstance_basic_deprecated_network(t *testing.T) {
 	var instance compute.Instance
 	var instanceName = fmt.Sprintf("instance-test-%s", acctest.RandString(10))
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log"
 	"net"
-	"regexp"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -60,36 +59,17 @@ func resourceContainerCluster() *schema.Resource {
 			},
 
 			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
-					value := v.(string)
-
-					if len(value) > 40 {
-						errors = append(errors, fmt.Errorf(
-							"%q cannot be longer than 40 characters", k))
-					}
-					if !regexp.MustCompile("^[a-z0-9-]+$").MatchString(value) {
-						errors = append(errors, fmt.Errorf(
-							"%q can only contain lowercase letters, numbers and hyphens", k))
-					}
-					if !regexp.MustCompile("^[a-z]").MatchString(value) {
-						errors = append(errors, fmt.Errorf(
-							"%q must start with a letter", k))
-					}
-					if !regexp.MustCompile("[a-z0-9]$").MatchString(value) {
-						errors = append(errors, fmt.Errorf(
-							"%q must end with a number or a letter", k))
-					}
-					return
-				},
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRFC1035Name(1, 40),
 			},
 
 			"zone": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateZone,
 			},
 
 			"cluster_ipv4_cidr": &schema.Schema{
@@ -191,46 +171,7 @@ func resourceContainerCluster() *schema.Resource {
 					},
 				},
 			},
-			"node_config": &schema.Schema{
-				Type:     schema.TypeList,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"machine_type": &schema.Schema{
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-							ForceNew: true,
-						},
-
-						"disk_size_gb": &schema.Schema{
-							Type:     schema.TypeInt,
-							Optional: true,
-							Computed: true,
-							ForceNew: true,
-							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
-								value := v.(int)
-
-								if value < 10 {
-									errors = append(errors, fmt.Errorf(
-										"%q cannot be less than 10", k))
-								}
-								return
-							},
-						},
-
-						"oauth_scopes": &schema.Schema{
-							Type:     schema.TypeList,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-							Optional: true,
-							Computed: true,
-							ForceNew: true,
-						},
-					},
-				},
-			},
+			"node_config": schemaNodeConfig,
 
 			"node_version": &schema.Schema{
 				Type:     schema.TypeString,
@@ -324,27 +265,7 @@ func resourceContainerClusterCreate(d *schema.ResourceData, meta interface{}) er
 		if len(nodeConfigs) > 1 {
 			return fmt.Errorf("Cannot specify more than one node_config.")
 		}
-		nodeConfig := nodeConfigs[0].(map[string]interface{})
-
-		cluster.NodeConfig = &container.NodeConfig{}
-
-		if v, ok = nodeConfig["machine_type"]; ok {
-			cluster.NodeConfig.MachineType = v.(string)
-		}
-
-		if v, ok = nodeConfig["disk_size_gb"]; ok {
-			cluster.NodeConfig.DiskSizeGb = int64(v.(int))
-		}
-
-		if v, ok := nodeConfig["oauth_scopes"]; ok {
-			scopesList := v.([]interface{})
-			scopes := []string{}
-			for _, v := range scopesList {
-				scopes = append(scopes, v.(string))
-			}
-
-			cluster.NodeConfig.OauthScopes = scopes
-		}
+		cluster.NodeConfig = expandNodeConfig(nodeConfigs[0])
 	}
 
 	req := &container.CreateClusterRequest{
@@ -532,18 +453,3 @@ func resourceContainerClusterDelete(d *schema.ResourceData, meta interface{}) er
 
 	return nil
 }
-
-func flattenClusterNodeConfig(c *container.NodeConfig) []map[string]interface{} {
-	config := []map[string]interface{}{
-		map[string]interface{}{
-			"machine_type": c.MachineType,
-			"disk_size_gb": c.DiskSizeGb,
-		},
-	}
-
-	if len(c.OauthScopes) > 0 {
-		config[0]["oauth_scopes"] = c.OauthScopes
-	}
-
-	return config
-}
@@ -0,0 +1,293 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/googleapi"
+)
+
+func resourceContainerNodePool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceContainerNodePoolCreate,
+		Read:   resourceContainerNodePoolRead,
+		Update: resourceContainerNodePoolUpdate,
+		Delete: resourceContainerNodePoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceContainerNodePoolImportState,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateRFC1035Name(1, 40),
+			},
+
+			"zone": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateZone,
+			},
+
+			"cluster": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"initial_node_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// The number of nodes per zone the pool currently has. Changing
+			// it resizes the pool's instance groups in place.
+			"node_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if v.(int) < 0 {
+						errors = append(errors, fmt.Errorf("%q cannot be negative", k))
+					}
+					return
+				},
+			},
+
+			"node_config": schemaNodeConfig,
+
+			"version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"instance_group_urls": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"project": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceContainerNodePoolCreate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	zoneName := d.Get("zone").(string)
+	clusterName := d.Get("cluster").(string)
+	poolName := d.Get("name").(string)
+
+	nodePool := &container.NodePool{
+		Name:             poolName,
+		InitialNodeCount: int64(d.Get("initial_node_count").(int)),
+	}
+
+	if v, ok := d.GetOk("node_config"); ok {
+		nodeConfigs := v.([]interface{})
+		if len(nodeConfigs) > 1 {
+			return fmt.Errorf("Cannot specify more than one node_config.")
+		}
+		nodePool.Config = expandNodeConfig(nodeConfigs[0])
+	}
+
+	req := &container.CreateNodePoolRequest{
+		NodePool: nodePool,
+	}
+
+	op, err := config.clientContainer.Projects.Zones.Clusters.NodePools.Create(
+		project, zoneName, clusterName, req).Do()
+	if err != nil {
+		return fmt.Errorf("Error creating GKE node pool: %s", err)
+	}
+
+	err = containerOperationWait(config, op, project, zoneName, "creating GKE node pool", 10, 3)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] GKE node pool %s has been created", poolName)
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", zoneName, clusterName, poolName))
+
+	// The pool is created with initial_node_count nodes; bring it to
+	// node_count straight away if that was set to something else. GetOk
+	// would mistake an explicit node_count = 0 for the field being unset.
+	if v, ok := d.GetOkExists("node_count"); ok && int64(v.(int)) != nodePool.InitialNodeCount {
+		return resourceContainerNodePoolUpdate(d, meta)
+	}
+
+	return resourceContainerNodePoolRead(d, meta)
+}
+
+func resourceContainerNodePoolRead(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	zoneName := d.Get("zone").(string)
+	clusterName := d.Get("cluster").(string)
+	poolName := d.Get("name").(string)
+
+	nodePool, err := config.clientContainer.Projects.Zones.Clusters.NodePools.Get(
+		project, zoneName, clusterName, poolName).Do()
+	if err != nil {
+		if gerr, ok := err.(*googleapi.Error); ok && gerr.Code == 404 {
+			log.Printf("[WARN] Removing GKE node pool %q because it's gone", d.Id())
+			// The resource doesn't exist anymore
+			d.SetId("")
+
+			return nil
+		}
+
+		return fmt.Errorf("Error reading GKE node pool %s: %s", d.Id(), err)
+	}
+
+	d.Set("name", nodePool.Name)
+	d.Set("initial_node_count", nodePool.InitialNodeCount)
+	d.Set("version", nodePool.Version)
+	d.Set("instance_group_urls", nodePool.InstanceGroupUrls)
+	d.Set("project", project)
+	if nodePool.Config != nil {
+		d.Set("node_config", flattenClusterNodeConfig(nodePool.Config))
+	}
+
+	// The container API doesn't report the current size of a pool, that's
+	// only known to the instance groups backing it. Every zone of the pool
+	// is kept at the same size, so the first group is enough.
+	if len(nodePool.InstanceGroupUrls) > 0 {
+		igmProject, igmZone, igmName, err := parseInstanceGroupManagerUrl(nodePool.InstanceGroupUrls[0])
+		if err != nil {
+			return err
+		}
+
+		igm, err := config.clientCompute.InstanceGroupManagers.Get(igmProject, igmZone, igmName).Do()
+		if err != nil {
+			return fmt.Errorf("Error reading instance group manager for GKE node pool %s: %s", d.Id(), err)
+		}
+		d.Set("node_count", igm.TargetSize)
+	}
+
+	return nil
+}
+
+func resourceContainerNodePoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	zoneName := d.Get("zone").(string)
+	clusterName := d.Get("cluster").(string)
+	poolName := d.Get("name").(string)
+	nodeCount := int64(d.Get("node_count").(int))
+
+	// Look the instance groups up rather than relying on
+	// instance_group_urls, which isn't known yet when called from create.
+	nodePool, err := config.clientContainer.Projects.Zones.Clusters.NodePools.Get(
+		project, zoneName, clusterName, poolName).Do()
+	if err != nil {
+		return fmt.Errorf("Error reading GKE node pool %s: %s", d.Id(), err)
+	}
+
+	for _, url := range nodePool.InstanceGroupUrls {
+		igmProject, igmZone, igmName, err := parseInstanceGroupManagerUrl(url)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[INFO] Resizing instance group manager %s of GKE node pool %s to %d", igmName, d.Id(), nodeCount)
+		op, err := config.clientCompute.InstanceGroupManagers.Resize(igmProject, igmZone, igmName, nodeCount).Do()
+		if err != nil {
+			return fmt.Errorf("Error resizing GKE node pool %s: %s", d.Id(), err)
+		}
+
+		err = computeOperationWaitZone(config, op, igmProject, igmZone, "Resizing GKE node pool")
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[INFO] GKE node pool %s has been resized to %d", d.Id(), nodeCount)
+
+	return resourceContainerNodePoolRead(d, meta)
+}
+
+func resourceContainerNodePoolDelete(d *schema.ResourceData, meta interface{}) error {
+	config := meta.(*Config)
+
+	project, err := getProject(d, config)
+	if err != nil {
+		return err
+	}
+
+	zoneName := d.Get("zone").(string)
+	clusterName := d.Get("cluster").(string)
+	poolName := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Deleting GKE node pool %s", d.Id())
+	op, err := config.clientContainer.Projects.Zones.Clusters.NodePools.Delete(
+		project, zoneName, clusterName, poolName).Do()
+	if err != nil {
+		return fmt.Errorf("Error deleting GKE node pool %s: %s", d.Id(), err)
+	}
+
+	err = containerOperationWait(config, op, project, zoneName, "deleting GKE node pool", 10, 3)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] GKE node pool %s has been deleted", d.Id())
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceContainerNodePoolImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("Invalid GKE node pool ID %q, expected {zone}/{cluster}/{name}", d.Id())
+	}
+
+	d.Set("zone", parts[0])
+	d.Set("cluster", parts[1])
+	d.Set("name", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseInstanceGroupManagerUrl splits the URL of a zonal instance group
+// manager, as listed in the instance groups of a node pool, into its
+// project, zone and name.
+func parseInstanceGroupManagerUrl(url string) (string, string, string, error) {
+	parts := strings.Split(url, "/")
+	n := len(parts)
+	if n < 6 || parts[n-6] != "projects" || parts[n-4] != "zones" || parts[n-2] != "instanceGroupManagers" {
+		return "", "", "", fmt.Errorf("Invalid instance group manager URL %q", url)
+	}
+	return parts[n-5], parts[n-3], parts[n-1], nil
+}
@@ -0,0 +1,155 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccContainerNodePool_basic(t *testing.T) {
+	cluster := fmt.Sprintf("tf-nodepool-test-%s", acctest.RandString(10))
+	np := fmt.Sprintf("tf-nodepool-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckContainerNodePoolDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccContainerNodePool_basic(cluster, np, 2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContainerNodePoolExists(
+						"google_container_node_pool.np"),
+					resource.TestCheckResourceAttr(
+						"google_container_node_pool.np", "node_count", "2"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccContainerNodePool_basic(cluster, np, 3),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContainerNodePoolExists(
+						"google_container_node_pool.np"),
+					resource.TestCheckResourceAttr(
+						"google_container_node_pool.np", "node_count", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccContainerNodePool_zeroNodeCount(t *testing.T) {
+	cluster := fmt.Sprintf("tf-nodepool-test-%s", acctest.RandString(10))
+	np := fmt.Sprintf("tf-nodepool-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckContainerNodePoolDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccContainerNodePool_basic(cluster, np, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckContainerNodePoolExists(
+						"google_container_node_pool.np"),
+					resource.TestCheckResourceAttr(
+						"google_container_node_pool.np", "node_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseInstanceGroupManagerUrl(t *testing.T) {
+	project, zone, name, err := parseInstanceGroupManagerUrl(
+		"https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instanceGroupManagers/gke-foo-default-pool-1234-grp")
+	if err != nil {
+		t.Fatalf("error: %s", err)
+	}
+	if project != "my-project" || zone != "us-central1-a" || name != "gke-foo-default-pool-1234-grp" {
+		t.Fatalf("unexpected result: %q, %q, %q", project, zone, name)
+	}
+
+	_, _, _, err = parseInstanceGroupManagerUrl(
+		"https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instanceGroups/foo")
+	if err == nil {
+		t.Fatalf("expected error for an instance group URL")
+	}
+}
+
+func testAccCheckContainerNodePoolDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_container_node_pool" {
+			continue
+		}
+
+		attributes := rs.Primary.Attributes
+		_, err := config.clientContainer.Projects.Zones.Clusters.NodePools.Get(
+			config.Project, attributes["zone"], attributes["cluster"], attributes["name"]).Do()
+		if err == nil {
+			return fmt.Errorf("GKE node pool still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckContainerNodePoolExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		config := testAccProvider.Meta().(*Config)
+
+		attributes := rs.Primary.Attributes
+		found, err := config.clientContainer.Projects.Zones.Clusters.NodePools.Get(
+			config.Project, attributes["zone"], attributes["cluster"], attributes["name"]).Do()
+		if err != nil {
+			return err
+		}
+
+		if found.Name != attributes["name"] {
+			return fmt.Errorf("GKE node pool not found")
+		}
+
+		return nil
+	}
+}
+
+func testAccContainerNodePool_basic(cluster, np string, nodeCount int) string {
+	return fmt.Sprintf(`
+resource "google_container_cluster" "cluster" {
+	name = "%s"
+	zone = "us-central1-a"
+	initial_node_count = 1
+
+	master_auth {
+		username = "mr.yoda"
+		password = "adoy.rm"
+	}
+}
+
+resource "google_container_node_pool" "np" {
+	name = "%s"
+	zone = "us-central1-a"
+	cluster = "${google_container_cluster.cluster.name}"
+	initial_node_count = 2
+	node_count = %d
+
+	node_config {
+		machine_type = "n1-standard-1"
+		disk_size_gb = 20
+	}
+}`, cluster, np, nodeCount)
+}
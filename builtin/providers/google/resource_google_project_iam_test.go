@@ -0,0 +1,89 @@
+package google
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// Grant a role to the project's default compute service account, which is
+// guaranteed to exist, and check it is revoked again on destroy.
+func TestAccGoogleProjectIamMember_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGoogleProjectIamMemberDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccGoogleProjectIamMember_basic, projectId),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGoogleProjectIamMemberExists("google_project_iam_member.foo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGoogleProjectIamMemberHasRole(rs *terraform.ResourceState) (bool, error) {
+	config := testAccProvider.Meta().(*Config)
+	p, err := getProjectIamPolicy(rs.Primary.Attributes["project"], config)
+	if err != nil {
+		return false, err
+	}
+
+	role := rs.Primary.Attributes["role"]
+	member := rs.Primary.Attributes["member"]
+	return rolesToMembersMap(p.Bindings)[role][member], nil
+}
+
+func testAccCheckGoogleProjectIamMemberExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		found, err := testAccGoogleProjectIamMemberHasRole(rs)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("Member %s does not have role %s",
+				rs.Primary.Attributes["member"], rs.Primary.Attributes["role"])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckGoogleProjectIamMemberDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "google_project_iam_member" {
+			continue
+		}
+
+		found, err := testAccGoogleProjectIamMemberHasRole(rs)
+		if err != nil {
+			return err
+		}
+		if found {
+			return fmt.Errorf("Member %s still has role %s",
+				rs.Primary.Attributes["member"], rs.Primary.Attributes["role"])
+		}
+	}
+
+	return nil
+}
+
+var testAccGoogleProjectIamMember_basic = `
+resource "google_project" "acceptance" {
+	id = "%s"
+}
+
+resource "google_project_iam_member" "foo" {
+	project = "${google_project.acceptance.id}"
+	role    = "roles/viewer"
+	member  = "serviceAccount:${google_project.acceptance.number}-compute@developer.gserviceaccount.com"
+}`
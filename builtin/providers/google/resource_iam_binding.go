@@ -0,0 +1,185 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+var IamBindingBaseSchema = map[string]*schema.Schema{
+	"role": &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	"members": &schema.Schema{
+		Type:     schema.TypeSet,
+		Required: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Set:      schema.HashString,
+	},
+	"etag": &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+}
+
+// ResourceIamBinding returns a resource that authoritatively manages the
+// members of a single role in the IAM policy of a parent resource. Bindings
+// for other roles are left untouched.
+func ResourceIamBinding(parentSpecificSchema map[string]*schema.Schema, newUpdaterFunc newResourceIamUpdaterFunc, parseIdFunc resourceIdParserFunc) *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIamBindingCreate(newUpdaterFunc),
+		Read:   resourceIamBindingRead(newUpdaterFunc),
+		Update: resourceIamBindingUpdate(newUpdaterFunc),
+		Delete: resourceIamBindingDelete(newUpdaterFunc),
+		Importer: &schema.ResourceImporter{
+			State: iamBindingImport(newUpdaterFunc, parseIdFunc),
+		},
+
+		Schema: mergeSchemas(IamBindingBaseSchema, parentSpecificSchema),
+	}
+}
+
+func resourceIamBindingCreate(newUpdaterFunc newResourceIamUpdaterFunc) schema.CreateFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		if err := setIamBinding(d, updater); err != nil {
+			return err
+		}
+
+		d.SetId(updater.GetResourceId() + "/" + d.Get("role").(string))
+		return resourceIamBindingRead(newUpdaterFunc)(d, meta)
+	}
+}
+
+func resourceIamBindingRead(newUpdaterFunc newResourceIamUpdaterFunc) schema.ReadFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		policy, err := updater.GetResourceIamPolicy()
+		if err != nil {
+			return err
+		}
+
+		role := d.Get("role").(string)
+		var binding *cloudresourcemanager.Binding
+		for _, b := range policy.Bindings {
+			if b.Role == role {
+				binding = b
+				break
+			}
+		}
+		if binding == nil {
+			log.Printf("[DEBUG] Binding for role %q not found in policy for %s, removing from state",
+				role, updater.DescribeResource())
+			d.SetId("")
+			return nil
+		}
+
+		d.Set("etag", policy.Etag)
+		d.Set("members", binding.Members)
+
+		return nil
+	}
+}
+
+func resourceIamBindingUpdate(newUpdaterFunc newResourceIamUpdaterFunc) schema.UpdateFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		if d.HasChange("members") {
+			if err := setIamBinding(d, updater); err != nil {
+				return err
+			}
+		}
+
+		return resourceIamBindingRead(newUpdaterFunc)(d, meta)
+	}
+}
+
+func resourceIamBindingDelete(newUpdaterFunc newResourceIamUpdaterFunc) schema.DeleteFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		role := d.Get("role").(string)
+		err = iamPolicyReadModifyWrite(updater, func(p *cloudresourcemanager.Policy) error {
+			p.Bindings = removeIamBinding(p.Bindings, role)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		d.SetId("")
+		return nil
+	}
+}
+
+// setIamBinding replaces the binding for the configured role, if any, with
+// one granting it to exactly the configured members.
+func setIamBinding(d *schema.ResourceData, updater ResourceIamUpdater) error {
+	binding := &cloudresourcemanager.Binding{
+		Role:    d.Get("role").(string),
+		Members: convertStringArr(d.Get("members").(*schema.Set).List()),
+	}
+
+	return iamPolicyReadModifyWrite(updater, func(p *cloudresourcemanager.Policy) error {
+		p.Bindings = append(removeIamBinding(p.Bindings, binding.Role), binding)
+		return nil
+	})
+}
+
+func removeIamBinding(bindings []*cloudresourcemanager.Binding, role string) []*cloudresourcemanager.Binding {
+	rb := make([]*cloudresourcemanager.Binding, 0, len(bindings))
+	for _, b := range bindings {
+		if b.Role != role {
+			rb = append(rb, b)
+		}
+	}
+	return rb
+}
+
+// iamBindingImport accepts IDs of the form "{parent id} {role}".
+func iamBindingImport(newUpdaterFunc newResourceIamUpdaterFunc, parseIdFunc resourceIdParserFunc) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		config := meta.(*Config)
+		parts := strings.Fields(d.Id())
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid IAM binding import ID %q, expected \"{id} {role}\"", d.Id())
+		}
+
+		if err := parseIdFunc(d, parts[0], config); err != nil {
+			return nil, err
+		}
+		d.Set("role", parts[1])
+
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(updater.GetResourceId() + "/" + parts[1])
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
@@ -0,0 +1,150 @@
+package google
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+var IamMemberBaseSchema = map[string]*schema.Schema{
+	"role": &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	"member": &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	"etag": &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+}
+
+// ResourceIamMember returns a resource that grants a single role to a
+// single member in the IAM policy of a parent resource. Other members of
+// the same role are left untouched.
+func ResourceIamMember(parentSpecificSchema map[string]*schema.Schema, newUpdaterFunc newResourceIamUpdaterFunc, parseIdFunc resourceIdParserFunc) *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIamMemberCreate(newUpdaterFunc),
+		Read:   resourceIamMemberRead(newUpdaterFunc),
+		Delete: resourceIamMemberDelete(newUpdaterFunc),
+		Importer: &schema.ResourceImporter{
+			State: iamMemberImport(newUpdaterFunc, parseIdFunc),
+		},
+
+		Schema: mergeSchemas(IamMemberBaseSchema, parentSpecificSchema),
+	}
+}
+
+func resourceIamMemberCreate(newUpdaterFunc newResourceIamUpdaterFunc) schema.CreateFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		role := d.Get("role").(string)
+		member := d.Get("member").(string)
+		err = iamPolicyReadModifyWrite(updater, func(p *cloudresourcemanager.Policy) error {
+			p.Bindings = mergeBindings(append(p.Bindings, &cloudresourcemanager.Binding{
+				Role:    role,
+				Members: []string{member},
+			}))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		d.SetId(updater.GetResourceId() + "/" + role + "/" + member)
+		return resourceIamMemberRead(newUpdaterFunc)(d, meta)
+	}
+}
+
+func resourceIamMemberRead(newUpdaterFunc newResourceIamUpdaterFunc) schema.ReadFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		policy, err := updater.GetResourceIamPolicy()
+		if err != nil {
+			return err
+		}
+
+		role := d.Get("role").(string)
+		member := d.Get("member").(string)
+		if !rolesToMembersMap(policy.Bindings)[role][member] {
+			log.Printf("[DEBUG] Member %q not found for role %q in policy for %s, removing from state",
+				member, role, updater.DescribeResource())
+			d.SetId("")
+			return nil
+		}
+
+		d.Set("etag", policy.Etag)
+
+		return nil
+	}
+}
+
+func resourceIamMemberDelete(newUpdaterFunc newResourceIamUpdaterFunc) schema.DeleteFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		role := d.Get("role").(string)
+		member := d.Get("member").(string)
+		err = iamPolicyReadModifyWrite(updater, func(p *cloudresourcemanager.Policy) error {
+			bm := rolesToMembersMap(p.Bindings)
+			delete(bm[role], member)
+			if len(bm[role]) == 0 {
+				delete(bm, role)
+			}
+			p.Bindings = rolesToMembersBinding(bm)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		d.SetId("")
+		return nil
+	}
+}
+
+// iamMemberImport accepts IDs of the form "{parent id} {role} {member}".
+func iamMemberImport(newUpdaterFunc newResourceIamUpdaterFunc, parseIdFunc resourceIdParserFunc) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		config := meta.(*Config)
+		parts := strings.Fields(d.Id())
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("Invalid IAM member import ID %q, expected \"{id} {role} {member}\"", d.Id())
+		}
+
+		if err := parseIdFunc(d, parts[0], config); err != nil {
+			return nil, err
+		}
+		d.Set("role", parts[1])
+		d.Set("member", parts[2])
+
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(updater.GetResourceId() + "/" + parts[1] + "/" + parts[2])
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
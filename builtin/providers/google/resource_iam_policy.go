@@ -0,0 +1,155 @@
+package google
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"google.golang.org/api/cloudresourcemanager/v1"
+)
+
+var IamPolicyBaseSchema = map[string]*schema.Schema{
+	"policy_data": &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		DiffSuppressFunc: iamPolicyDataDiffSuppress,
+	},
+	"etag": &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	},
+}
+
+// ResourceIamPolicy returns a resource that authoritatively manages the whole
+// IAM policy of a parent resource. parentSpecificSchema describes the
+// arguments identifying the parent, and newUpdaterFunc builds the updater
+// that talks to its API.
+func ResourceIamPolicy(parentSpecificSchema map[string]*schema.Schema, newUpdaterFunc newResourceIamUpdaterFunc, parseIdFunc resourceIdParserFunc) *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIamPolicyCreate(newUpdaterFunc),
+		Read:   resourceIamPolicyRead(newUpdaterFunc),
+		Update: resourceIamPolicyUpdate(newUpdaterFunc),
+		Delete: resourceIamPolicyDelete(newUpdaterFunc),
+		Importer: &schema.ResourceImporter{
+			State: iamPolicyImport(newUpdaterFunc, parseIdFunc),
+		},
+
+		Schema: mergeSchemas(IamPolicyBaseSchema, parentSpecificSchema),
+	}
+}
+
+func resourceIamPolicyCreate(newUpdaterFunc newResourceIamUpdaterFunc) schema.CreateFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		if err := setIamPolicyData(d, updater); err != nil {
+			return err
+		}
+
+		d.SetId(updater.GetResourceId())
+		return resourceIamPolicyRead(newUpdaterFunc)(d, meta)
+	}
+}
+
+func resourceIamPolicyRead(newUpdaterFunc newResourceIamUpdaterFunc) schema.ReadFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		policy, err := updater.GetResourceIamPolicy()
+		if err != nil {
+			return err
+		}
+
+		policyData, err := marshalIamPolicy(policy)
+		if err != nil {
+			return err
+		}
+
+		d.Set("etag", policy.Etag)
+		d.Set("policy_data", policyData)
+
+		return nil
+	}
+}
+
+func resourceIamPolicyUpdate(newUpdaterFunc newResourceIamUpdaterFunc) schema.UpdateFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		if d.HasChange("policy_data") {
+			if err := setIamPolicyData(d, updater); err != nil {
+				return err
+			}
+		}
+
+		return resourceIamPolicyRead(newUpdaterFunc)(d, meta)
+	}
+}
+
+func resourceIamPolicyDelete(newUpdaterFunc newResourceIamUpdaterFunc) schema.DeleteFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		config := meta.(*Config)
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return err
+		}
+
+		if updater.ClearPolicyOnDelete() {
+			err = iamPolicyReadModifyWrite(updater, func(p *cloudresourcemanager.Policy) error {
+				p.Bindings = nil
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		} else {
+			log.Printf("[WARN] Leaving IAM policy of %s in place, it is only removed from state", updater.DescribeResource())
+		}
+
+		d.SetId("")
+		return nil
+	}
+}
+
+// setIamPolicyData replaces the bindings of the parent's policy with the
+// ones in policy_data, keeping the current etag so that the write fails
+// instead of clobbering a concurrent change.
+func setIamPolicyData(d *schema.ResourceData, updater ResourceIamUpdater) error {
+	policy, err := unmarshalIamPolicy(d.Get("policy_data").(string))
+	if err != nil {
+		return err
+	}
+
+	return iamPolicyReadModifyWrite(updater, func(p *cloudresourcemanager.Policy) error {
+		p.Bindings = policy.Bindings
+		return nil
+	})
+}
+
+func iamPolicyImport(newUpdaterFunc newResourceIamUpdaterFunc, parseIdFunc resourceIdParserFunc) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		config := meta.(*Config)
+		if err := parseIdFunc(d, d.Id(), config); err != nil {
+			return nil, err
+		}
+
+		updater, err := newUpdaterFunc(d, config)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(updater.GetResourceId())
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
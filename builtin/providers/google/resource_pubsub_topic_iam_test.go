@@ -0,0 +1,172 @@
+package google
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccPubsubTopicIamBinding(t *testing.T) {
+	topic := "tf-test-topic-iam-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccPubsubTopicIamBinding_basic(topic),
+				Check: testAccCheckPubsubTopicIam(topic, "roles/pubsub.publisher", []string{
+					"allAuthenticatedUsers",
+				}),
+			},
+			resource.TestStep{
+				Config: testAccPubsubTopicIamBinding_update(topic),
+				Check: testAccCheckPubsubTopicIam(topic, "roles/pubsub.publisher", []string{
+					"allAuthenticatedUsers",
+					"allUsers",
+				}),
+			},
+			resource.TestStep{
+				ResourceName:      "google_pubsub_topic_iam_binding.foo",
+				ImportStateId:     fmt.Sprintf("projects/%s/topics/%s roles/pubsub.publisher", projectId, topic),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPubsubTopicIamMember(t *testing.T) {
+	topic := "tf-test-topic-iam-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccPubsubTopicIamMember_basic(topic),
+				Check: testAccCheckPubsubTopicIam(topic, "roles/pubsub.subscriber", []string{
+					"allAuthenticatedUsers",
+				}),
+			},
+			resource.TestStep{
+				ResourceName:      "google_pubsub_topic_iam_member.foo",
+				ImportStateId:     fmt.Sprintf("projects/%s/topics/%s roles/pubsub.subscriber allAuthenticatedUsers", projectId, topic),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPubsubTopicIamPolicy(t *testing.T) {
+	topic := "tf-test-topic-iam-" + acctest.RandString(10)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccPubsubTopicIamPolicy_basic(topic),
+				Check: testAccCheckPubsubTopicIam(topic, "roles/pubsub.viewer", []string{
+					"allAuthenticatedUsers",
+				}),
+			},
+			resource.TestStep{
+				ResourceName:      "google_pubsub_topic_iam_policy.foo",
+				ImportStateId:     fmt.Sprintf("projects/%s/topics/%s", projectId, topic),
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckPubsubTopicIam(topic, role string, members []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := testAccProvider.Meta().(*Config)
+		name := fmt.Sprintf("projects/%s/topics/%s", projectId, topic)
+		p, err := config.clientPubsub.Projects.Topics.GetIamPolicy(name).Do()
+		if err != nil {
+			return err
+		}
+
+		for _, binding := range p.Bindings {
+			if binding.Role == role {
+				sort.Strings(members)
+				sort.Strings(binding.Members)
+
+				if reflect.DeepEqual(members, binding.Members) {
+					return nil
+				}
+
+				return fmt.Errorf("Binding found but expected members is %v, got %v", members, binding.Members)
+			}
+		}
+
+		return fmt.Errorf("No binding for role %q", role)
+	}
+}
+
+func testAccPubsubTopicIamBinding_basic(topic string) string {
+	return fmt.Sprintf(`
+resource "google_pubsub_topic" "topic" {
+	name = "%s"
+}
+
+resource "google_pubsub_topic_iam_binding" "foo" {
+	topic   = "${google_pubsub_topic.topic.id}"
+	role    = "roles/pubsub.publisher"
+	members = ["allAuthenticatedUsers"]
+}`, topic)
+}
+
+func testAccPubsubTopicIamBinding_update(topic string) string {
+	return fmt.Sprintf(`
+resource "google_pubsub_topic" "topic" {
+	name = "%s"
+}
+
+resource "google_pubsub_topic_iam_binding" "foo" {
+	topic   = "${google_pubsub_topic.topic.id}"
+	role    = "roles/pubsub.publisher"
+	members = ["allAuthenticatedUsers", "allUsers"]
+}`, topic)
+}
+
+func testAccPubsubTopicIamMember_basic(topic string) string {
+	return fmt.Sprintf(`
+resource "google_pubsub_topic" "topic" {
+	name = "%s"
+}
+
+resource "google_pubsub_topic_iam_member" "foo" {
+	topic  = "${google_pubsub_topic.topic.id}"
+	role   = "roles/pubsub.subscriber"
+	member = "allAuthenticatedUsers"
+}`, topic)
+}
+
+func testAccPubsubTopicIamPolicy_basic(topic string) string {
+	return fmt.Sprintf(`
+resource "google_pubsub_topic" "topic" {
+	name = "%s"
+}
+
+data "google_iam_policy" "foo" {
+	binding {
+		role    = "roles/pubsub.viewer"
+		members = ["allAuthenticatedUsers"]
+	}
+}
+
+resource "google_pubsub_topic_iam_policy" "foo" {
+	topic       = "${google_pubsub_topic.topic.id}"
+	policy_data = "${data.google_iam_policy.foo.policy_data}"
+}`, topic)
+}
@@ -63,6 +63,71 @@ func resourceStorageBucket() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"lifecycle_rule": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 100,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateAllowedStringValue([]string{"Delete"}),
+									},
+								},
+							},
+						},
+
+						"condition": &schema.Schema{
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"age": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+									"created_before": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"is_live": &schema.Schema{
+										Type:     schema.TypeBool,
+										Optional: true,
+									},
+									"num_newer_versions": &schema.Schema{
+										Type:     schema.TypeInt,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"versioning": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+
 			"website": &schema.Schema{
 				Type:     schema.TypeList,
 				Optional: true,
@@ -122,6 +187,14 @@ func resourceStorageBucketCreate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	if v, ok := d.GetOk("lifecycle_rule"); ok {
+		sb.Lifecycle = expandStorageBucketLifecycle(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("versioning"); ok {
+		sb.Versioning = expandStorageBucketVersioning(v.([]interface{}))
+	}
+
 	call := config.clientStorage.Buckets.Insert(project, sb)
 	if v, ok := d.GetOk("predefined_acl"); ok {
 		call = call.PredefinedAcl(v.(string))
@@ -179,6 +252,18 @@ func resourceStorageBucketUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	if d.HasChange("lifecycle_rule") {
+		sb.Lifecycle = expandStorageBucketLifecycle(d.Get("lifecycle_rule").([]interface{}))
+		// An empty list of rules has to be sent explicitly for the
+		// PATCH call to remove the existing ones.
+		sb.Lifecycle.ForceSendFields = []string{"Rule"}
+	}
+
+	if d.HasChange("versioning") {
+		sb.Versioning = expandStorageBucketVersioning(d.Get("versioning").([]interface{}))
+		sb.Versioning.ForceSendFields = []string{"Enabled"}
+	}
+
 	res, err := config.clientStorage.Buckets.Patch(d.Get("name").(string), sb).Do()
 
 	if err != nil {
@@ -215,6 +300,13 @@ func resourceStorageBucketRead(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[DEBUG] Read bucket %v at location %v\n\n", res.Name, res.SelfLink)
 
+	if err := d.Set("lifecycle_rule", flattenStorageBucketLifecycle(res.Lifecycle)); err != nil {
+		return fmt.Errorf("Error setting lifecycle_rule for bucket %s: %s", bucket, err)
+	}
+	if err := d.Set("versioning", flattenStorageBucketVersioning(d, res.Versioning)); err != nil {
+		return fmt.Errorf("Error setting versioning for bucket %s: %s", bucket, err)
+	}
+
 	// Update the bucket ID according to the resource ID
 	d.Set("self_link", res.SelfLink)
 	d.SetId(res.Id)
@@ -269,3 +361,83 @@ func resourceStorageBucketDelete(d *schema.ResourceData, meta interface{}) error
 
 	return nil
 }
+
+func expandStorageBucketLifecycle(rules []interface{}) *storage.BucketLifecycle {
+	lifecycle := &storage.BucketLifecycle{
+		Rule: make([]*storage.BucketLifecycleRule, 0, len(rules)),
+	}
+
+	for _, raw := range rules {
+		rule := raw.(map[string]interface{})
+		action := rule["action"].([]interface{})[0].(map[string]interface{})
+		condition := rule["condition"].([]interface{})[0].(map[string]interface{})
+
+		lifecycle.Rule = append(lifecycle.Rule, &storage.BucketLifecycleRule{
+			Action: &storage.BucketLifecycleRuleAction{
+				Type: action["type"].(string),
+			},
+			Condition: &storage.BucketLifecycleRuleCondition{
+				Age:              int64(condition["age"].(int)),
+				CreatedBefore:    condition["created_before"].(string),
+				IsLive:           condition["is_live"].(bool),
+				NumNewerVersions: int64(condition["num_newer_versions"].(int)),
+			},
+		})
+	}
+
+	return lifecycle
+}
+
+func flattenStorageBucketLifecycle(lifecycle *storage.BucketLifecycle) []map[string]interface{} {
+	rules := []map[string]interface{}{}
+	if lifecycle == nil {
+		return rules
+	}
+
+	for _, rule := range lifecycle.Rule {
+		action := map[string]interface{}{}
+		if rule.Action != nil {
+			action["type"] = rule.Action.Type
+		}
+
+		condition := map[string]interface{}{}
+		if rule.Condition != nil {
+			condition["age"] = rule.Condition.Age
+			condition["created_before"] = rule.Condition.CreatedBefore
+			condition["is_live"] = rule.Condition.IsLive
+			condition["num_newer_versions"] = rule.Condition.NumNewerVersions
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"action":    []map[string]interface{}{action},
+			"condition": []map[string]interface{}{condition},
+		})
+	}
+
+	return rules
+}
+
+func expandStorageBucketVersioning(configured []interface{}) *storage.BucketVersioning {
+	versioning := &storage.BucketVersioning{}
+	if len(configured) > 0 && configured[0] != nil {
+		versioning.Enabled = configured[0].(map[string]interface{})["enabled"].(bool)
+	}
+	return versioning
+}
+
+// flattenStorageBucketVersioning treats a missing versioning block the same
+// as a disabled one. A block is only returned for disabled versioning if
+// the state already has one, so that neither form shows up as a diff.
+func flattenStorageBucketVersioning(d *schema.ResourceData, versioning *storage.BucketVersioning) []map[string]interface{} {
+	if versioning != nil && versioning.Enabled {
+		return []map[string]interface{}{
+			{"enabled": true},
+		}
+	}
+	if len(d.Get("versioning").([]interface{})) > 0 {
+		return []map[string]interface{}{
+			{"enabled": false},
+		}
+	}
+	return nil
+}
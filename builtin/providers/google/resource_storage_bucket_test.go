@@ -3,6 +3,8 @@ package google
 import (
 	"bytes"
 	"fmt"
+	"log"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -13,6 +15,72 @@ import (
 	storage "google.golang.org/api/storage/v1"
 )
 
+func init() {
+	resource.AddTestSweepers("gcp_storage_bucket", &resource.Sweeper{
+		Name: "gcp_storage_bucket",
+		F:    testSweepStorageBuckets,
+	})
+}
+
+func testSweepStorageBuckets(region string) error {
+	config, err := sharedConfigForRegion(region)
+	if err != nil {
+		return err
+	}
+
+	pageToken := ""
+	for {
+		call := config.clientStorage.Buckets.List(config.Project).Prefix("tf-test-")
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		res, err := call.Do()
+		if err != nil {
+			return fmt.Errorf("Error listing storage buckets: %s", err)
+		}
+
+		for _, bucket := range res.Items {
+			if !strings.HasPrefix(bucket.Name, "tf-test-") {
+				continue
+			}
+
+			log.Printf("[INFO] Sweeping storage bucket %s", bucket.Name)
+			if err := testSweepStorageBucketObjects(config, bucket.Name); err != nil {
+				return err
+			}
+			if err := config.clientStorage.Buckets.Delete(bucket.Name).Do(); err != nil {
+				return fmt.Errorf("Error deleting storage bucket %s: %s", bucket.Name, err)
+			}
+		}
+
+		if res.NextPageToken == "" {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+
+	return nil
+}
+
+func testSweepStorageBucketObjects(config *Config, bucket string) error {
+	for {
+		res, err := config.clientStorage.Objects.List(bucket).Versions(true).Do()
+		if err != nil {
+			return fmt.Errorf("Error listing objects in storage bucket %s: %s", bucket, err)
+		}
+		if len(res.Items) == 0 {
+			return nil
+		}
+
+		for _, object := range res.Items {
+			call := config.clientStorage.Objects.Delete(bucket, object.Name).Generation(object.Generation)
+			if err := call.Do(); err != nil {
+				return fmt.Errorf("Error deleting object %s in storage bucket %s: %s", object.Name, bucket, err)
+			}
+		}
+	}
+}
+
 func TestAccStorage_basic(t *testing.T) {
 	bucketName := fmt.Sprintf("tf-test-acl-bucket-%d", acctest.RandInt())
 
@@ -165,6 +233,106 @@ func TestAccStorageForceDestroy(t *testing.T) {
 	})
 }
 
+func TestAccStorageBucketLifecycleRules(t *testing.T) {
+	bucketName := fmt.Sprintf("tf-test-acl-bucket-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGoogleStorageDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testGoogleStorageBucketsLifecycleRules(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStorageBucketExists(
+						"google_storage_bucket.bucket", bucketName),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "lifecycle_rule.#", "2"),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "lifecycle_rule.0.condition.0.age", "10"),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "lifecycle_rule.1.condition.0.num_newer_versions", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: testGoogleStorageBucketsReaderDefaults(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStorageBucketExists(
+						"google_storage_bucket.bucket", bucketName),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "lifecycle_rule.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccStorageBucketVersioning(t *testing.T) {
+	bucketName := fmt.Sprintf("tf-test-acl-bucket-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccGoogleStorageDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testGoogleStorageBucketsVersioning(bucketName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStorageBucketExists(
+						"google_storage_bucket.bucket", bucketName),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "versioning.0.enabled", "true"),
+					testAccCheckStorageBucketVersioning(bucketName, true),
+				),
+			},
+			resource.TestStep{
+				Config: testGoogleStorageBucketsVersioning(bucketName, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStorageBucketExists(
+						"google_storage_bucket.bucket", bucketName),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "versioning.0.enabled", "false"),
+					testAccCheckStorageBucketVersioning(bucketName, false),
+				),
+			},
+			resource.TestStep{
+				Config: testGoogleStorageBucketsVersioning(bucketName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStorageBucketVersioning(bucketName, true),
+				),
+			},
+			// Removing the block turns versioning off again.
+			resource.TestStep{
+				Config: testGoogleStorageBucketsReaderDefaults(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCloudStorageBucketExists(
+						"google_storage_bucket.bucket", bucketName),
+					resource.TestCheckResourceAttr(
+						"google_storage_bucket.bucket", "versioning.#", "0"),
+					testAccCheckStorageBucketVersioning(bucketName, false),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckStorageBucketVersioning(bucketName string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config := testAccProvider.Meta().(*Config)
+
+		res, err := config.clientStorage.Buckets.Get(bucketName).Do()
+		if err != nil {
+			return err
+		}
+
+		actual := res.Versioning != nil && res.Versioning.Enabled
+		if actual != enabled {
+			return fmt.Errorf("expected versioning of bucket %s to be enabled=%t, got %t", bucketName, enabled, actual)
+		}
+		return nil
+	}
+}
+
 func testAccCheckCloudStorageBucketExists(n string, bucketName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -274,3 +442,43 @@ resource "google_storage_bucket" "bucket" {
 }
 `, bucketName, storageClass)
 }
+
+func testGoogleStorageBucketsLifecycleRules(bucketName string) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+
+	lifecycle_rule {
+		action {
+			type = "Delete"
+		}
+		condition {
+			age = 10
+			is_live = true
+		}
+	}
+
+	lifecycle_rule {
+		action {
+			type = "Delete"
+		}
+		condition {
+			created_before = "2017-01-01"
+			num_newer_versions = 2
+		}
+	}
+}
+`, bucketName)
+}
+
+func testGoogleStorageBucketsVersioning(bucketName string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "google_storage_bucket" "bucket" {
+	name = "%s"
+
+	versioning {
+		enabled = %t
+	}
+}
+`, bucketName, enabled)
+}
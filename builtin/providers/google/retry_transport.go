@@ -0,0 +1,183 @@
+package google
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	retryMinBackoff = 1 * time.Second
+	retryMaxBackoff = 30 * time.Second
+
+	// retryMaxBufferedBody is the largest request body, in bytes, that is
+	// buffered so that it can be replayed when it can't be recreated.
+	retryMaxBufferedBody = 1 << 20
+)
+
+var errRetryCancelled = errors.New("request cancelled while waiting to retry")
+
+// retryTransport is an http.RoundTripper that retries requests failing with
+// a rate limit or, for idempotent requests, a transient server error,
+// backing off exponentially between attempts. It is installed underneath
+// every API client created in Config, so all resources get the same retry
+// behaviour without having to wrap their own calls.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, maxRetries int) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &retryTransport{
+		base:       base,
+		maxRetries: maxRetries,
+		minBackoff: retryMinBackoff,
+		maxBackoff: retryMaxBackoff,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Media uploads are left alone: gensupport retries the chunks of
+	// resumable uploads with its own backoff, and retrying them here too
+	// would multiply the attempts made for every chunk.
+	if t.maxRetries <= 0 || req.URL.Query().Get("uploadType") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	// The body has to be replayed on every attempt. Requests built from an
+	// in-memory reader can recreate it with GetBody; otherwise only small
+	// bodies of known length are buffered, and anything else is sent once.
+	getBody := req.GetBody
+	buffered := false
+	if req.Body != nil && req.Body != http.NoBody && getBody == nil {
+		if req.ContentLength <= 0 || req.ContentLength > retryMaxBufferedBody {
+			return t.base.RoundTrip(req)
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		getBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+		buffered = true
+	}
+
+	backoff := t.minBackoff
+	for attempt := 0; ; attempt++ {
+		// A RoundTripper must not modify the caller's request, so send a
+		// copy carrying a fresh body.
+		r := *req
+		if getBody != nil && (attempt > 0 || buffered) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = body
+		}
+
+		res, err := t.base.RoundTrip(&r)
+		if attempt >= t.maxRetries || !isRetryableResponse(req.Method, res, err) {
+			return res, err
+		}
+
+		wait := backoff + time.Duration(rand.Int63n(int64(backoff)/2+1))
+		if res != nil {
+			if after := retryAfter(res); after > wait {
+				wait = after
+			}
+			res.Body.Close()
+			log.Printf("[DEBUG] %s %s returned %d, retrying in %s (attempt %d of %d)",
+				req.Method, req.URL, res.StatusCode, wait, attempt+1, t.maxRetries)
+		} else {
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s (attempt %d of %d)",
+				req.Method, req.URL, err, wait, attempt+1, t.maxRetries)
+		}
+
+		// Give up early if the request is cancelled or times out while
+		// waiting, rather than sleeping through a long Retry-After.
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-req.Cancel:
+			return nil, errRetryCancelled
+		}
+
+		backoff *= 2
+		if backoff > t.maxBackoff {
+			backoff = t.maxBackoff
+		}
+	}
+}
+
+// isIdempotentMethod reports whether a request with the given method can be
+// sent again without side effects if the first attempt reached the server.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// isRetryableResponse reports whether a request that produced res and err
+// is worth retrying. Rate limited requests were not processed and are
+// always retried. Transient server errors and temporary network failures
+// may have happened after the server acted on the request, so they are only
+// retried for idempotent methods; retrying an insert could fail with a 409
+// or create a duplicate outside of the state.
+func isRetryableResponse(method string, res *http.Response, err error) bool {
+	if err != nil {
+		if nerr, ok := err.(net.Error); ok {
+			return nerr.Temporary() && isIdempotentMethod(method)
+		}
+		return false
+	}
+
+	switch res.StatusCode {
+	case 429:
+		return true
+	case 500, 502, 503, 504:
+		return isIdempotentMethod(method)
+	case 403:
+		// Several Google APIs report quota exhaustion as a 403 with a
+		// rateLimitExceeded or userRateLimitExceeded reason, which is
+		// indistinguishable from a permissions error without the body.
+		b, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = ioutil.NopCloser(bytes.NewReader(b))
+		if err != nil {
+			return false
+		}
+		return bytes.Contains(b, []byte(`"rateLimitExceeded"`)) ||
+			bytes.Contains(b, []byte(`"userRateLimitExceeded"`))
+	}
+
+	return false
+}
+
+// retryAfter returns the delay requested by a Retry-After header given in
+// seconds, or zero if there is none.
+func retryAfter(res *http.Response) time.Duration {
+	secs, err := strconv.Atoi(res.Header.Get("Retry-After"))
+	if err != nil || secs < 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
@@ -0,0 +1,172 @@
+package google
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRetryServer(t *testing.T, responses []int, body string) (*httptest.Server, *int) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("error reading request body: %s", err)
+		}
+		if string(b) != "payload" {
+			t.Errorf("request body not replayed, got %q", string(b))
+		}
+
+		code := responses[len(responses)-1]
+		if calls < len(responses) {
+			code = responses[calls]
+		}
+		calls++
+
+		w.WriteHeader(code)
+		w.Write([]byte(body))
+	}))
+
+	return ts, &calls
+}
+
+func testRetryClient(maxRetries int) *http.Client {
+	rt := newRetryTransport(nil, maxRetries)
+	rt.minBackoff = time.Millisecond
+	rt.maxBackoff = time.Millisecond
+	return &http.Client{Transport: rt}
+}
+
+func TestRetryTransport(t *testing.T) {
+	cases := []struct {
+		Method     string
+		Responses  []int
+		Body       string
+		MaxRetries int
+		Calls      int
+		StatusCode int
+	}{
+		{"PUT", []int{200}, "", 5, 1, 200},
+		{"PUT", []int{503, 502, 200}, "", 5, 3, 200},
+		{"PUT", []int{429, 200}, "", 5, 2, 200},
+		{"PUT", []int{500}, "", 2, 3, 500},
+		{"PUT", []int{503, 200}, "", 0, 1, 503},
+		{"PUT", []int{404}, "", 5, 1, 404},
+		{"PUT", []int{400}, "", 5, 1, 400},
+		{"PUT", []int{403, 200}, `{"error": {"errors": [{"reason": "rateLimitExceeded"}]}}`, 5, 2, 200},
+		{"PUT", []int{403, 200}, `{"error": {"errors": [{"reason": "userRateLimitExceeded"}]}}`, 5, 2, 200},
+		{"PUT", []int{403, 200}, `{"error": {"errors": [{"reason": "forbidden"}]}}`, 5, 1, 403},
+		{"DELETE", []int{503, 200}, "", 5, 2, 200},
+
+		// Non-idempotent requests are only retried when they were rejected
+		// without being processed.
+		{"POST", []int{200}, "", 5, 1, 200},
+		{"POST", []int{429, 200}, "", 5, 2, 200},
+		{"POST", []int{403, 200}, `{"error": {"errors": [{"reason": "rateLimitExceeded"}]}}`, 5, 2, 200},
+		{"POST", []int{503, 200}, "", 5, 1, 503},
+		{"POST", []int{500, 200}, "", 5, 1, 500},
+		{"PATCH", []int{502, 200}, "", 5, 1, 502},
+		{"PATCH", []int{429, 200}, "", 5, 2, 200},
+	}
+
+	for i, tc := range cases {
+		ts, calls := testRetryServer(t, tc.Responses, tc.Body)
+
+		req, err := http.NewRequest(tc.Method, ts.URL, strings.NewReader("payload"))
+		if err != nil {
+			t.Fatalf("%d: error: %s", i, err)
+		}
+		reqBody := req.Body
+
+		res, err := testRetryClient(tc.MaxRetries).Do(req)
+		if err != nil {
+			t.Fatalf("%d: error: %s", i, err)
+		}
+
+		if req.Body != reqBody {
+			t.Errorf("%d: the caller's request was modified", i)
+		}
+
+		if res.StatusCode != tc.StatusCode {
+			t.Errorf("%d: expected status %d, got %d", i, tc.StatusCode, res.StatusCode)
+		}
+		if *calls != tc.Calls {
+			t.Errorf("%d: expected %d calls, got %d", i, tc.Calls, *calls)
+		}
+
+		// The body of an inspected 403 must still be readable by the caller.
+		b, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if string(b) != tc.Body {
+			t.Errorf("%d: expected body %q, got %q", i, tc.Body, string(b))
+		}
+
+		ts.Close()
+	}
+}
+
+func TestRetryTransport_body(t *testing.T) {
+	cases := []struct {
+		URL           string
+		ContentLength int64
+		GetBody       bool
+		Calls         int
+	}{
+		// Bodies that can be recreated or buffered are replayed.
+		{"/", 7, true, 3},
+		{"/", 7, false, 3},
+		// Bodies of unknown length can't be replayed, so they're sent once.
+		{"/", -1, false, 1},
+		// Resumable upload chunks are retried by gensupport instead.
+		{"/?uploadType=resumable&upload_id=abc", 7, true, 1},
+	}
+
+	for i, tc := range cases {
+		ts, calls := testRetryServer(t, []int{503, 503, 200}, "")
+
+		req, err := http.NewRequest("PUT", ts.URL+tc.URL, strings.NewReader("payload"))
+		if err != nil {
+			t.Fatalf("%d: error: %s", i, err)
+		}
+		req.ContentLength = tc.ContentLength
+		if !tc.GetBody {
+			req.GetBody = nil
+			req.Body = ioutil.NopCloser(strings.NewReader("payload"))
+		}
+
+		res, err := testRetryClient(5).Do(req)
+		if err != nil {
+			t.Fatalf("%d: error: %s", i, err)
+		}
+		res.Body.Close()
+
+		if *calls != tc.Calls {
+			t.Errorf("%d: expected %d calls, got %d", i, tc.Calls, *calls)
+		}
+
+		ts.Close()
+	}
+}
+
+func TestRetryTransport_cancel(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(429)
+	}))
+	defer ts.Close()
+
+	client := testRetryClient(5)
+	client.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	_, err := client.Get(ts.URL)
+	if err == nil {
+		t.Fatalf("expected the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the retry wait to be cut short by the timeout, took %s", elapsed)
+	}
+}
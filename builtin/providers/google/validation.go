@@ -0,0 +1,108 @@
+package google
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+const (
+	// Names of most Compute Engine and Container Engine resources have to
+	// comply with RFC1035: a lowercase letter followed by lowercase letters,
+	// digits or dashes, not ending with a dash.
+	rfc1035NameRegex = `^[a-z](?:[-a-z0-9]*[a-z0-9])?$`
+
+	regionRegex = `^[a-z]+-[a-z]+[0-9]+$`
+	zoneRegex   = `^[a-z]+-[a-z]+[0-9]+-[a-z]$`
+)
+
+// validateAllowedStringValue returns a SchemaValidateFunc that checks the
+// value is one of the given strings.
+func validateAllowedStringValue(ss []string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		for _, s := range ss {
+			if value == s {
+				return
+			}
+		}
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %s, got %q", k, strings.Join(ss, ", "), value))
+		return
+	}
+}
+
+// validateRegexp returns a SchemaValidateFunc that checks the value matches
+// the given regular expression.
+func validateRegexp(re string) schema.SchemaValidateFunc {
+	r := regexp.MustCompile(re)
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		if !r.MatchString(value) {
+			errors = append(errors, fmt.Errorf(
+				"%q (%q) doesn't match regexp %q", k, value, re))
+		}
+		return
+	}
+}
+
+// validateRFC1035Name returns a SchemaValidateFunc that checks the value is
+// an RFC1035 name between min and max characters long.
+func validateRFC1035Name(min, max int) schema.SchemaValidateFunc {
+	r := regexp.MustCompile(rfc1035NameRegex)
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		value := v.(string)
+		if len(value) < min {
+			errors = append(errors, fmt.Errorf(
+				"%q cannot be shorter than %d characters", k, min))
+		}
+		if len(value) > max {
+			errors = append(errors, fmt.Errorf(
+				"%q cannot be longer than %d characters", k, max))
+		}
+		if !r.MatchString(value) {
+			errors = append(errors, fmt.Errorf(
+				"%q must start with a lowercase letter, contain only lowercase letters, numbers and hyphens, and not end with a hyphen", k))
+		}
+		return
+	}
+}
+
+// validateGCSPath checks the value is a Cloud Storage path of the form
+// gs://bucket or gs://bucket/object.
+func validateGCSPath(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !strings.HasPrefix(value, "gs://") {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a Cloud Storage path starting with gs://, got %q", k, value))
+		return
+	}
+	bucket := strings.SplitN(strings.TrimPrefix(value, "gs://"), "/", 2)[0]
+	if bucket == "" {
+		errors = append(errors, fmt.Errorf(
+			"%q must include a bucket name, got %q", k, value))
+	}
+	return
+}
+
+// validateRegion checks the value looks like a region, e.g. us-central1.
+func validateRegion(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(regionRegex).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a region such as us-central1, got %q", k, value))
+	}
+	return
+}
+
+// validateZone checks the value looks like a zone, e.g. us-central1-a.
+func validateZone(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(zoneRegex).MatchString(value) {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a zone such as us-central1-a, got %q", k, value))
+	}
+	return
+}
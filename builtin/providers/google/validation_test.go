@@ -0,0 +1,56 @@
+package google
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestValidation(t *testing.T) {
+	cases := []struct {
+		F     schema.SchemaValidateFunc
+		Value string
+		Valid bool
+	}{
+		{validateAllowedStringValue([]string{"DAY"}), "DAY", true},
+		{validateAllowedStringValue([]string{"READER", "WRITER"}), "WRITER", true},
+		{validateAllowedStringValue([]string{"DAY"}), "day", false},
+		{validateAllowedStringValue([]string{"DAY"}), "", false},
+
+		{validateRegexp(`^[a-z]+$`), "abc", true},
+		{validateRegexp(`^[a-z]+$`), "abc1", false},
+
+		{validateRFC1035Name(1, 63), "a", true},
+		{validateRFC1035Name(1, 63), "my-name-1", true},
+		{validateRFC1035Name(1, 63), "", false},
+		{validateRFC1035Name(1, 63), "1name", false},
+		{validateRFC1035Name(1, 63), "name-", false},
+		{validateRFC1035Name(1, 63), "My-name", false},
+		{validateRFC1035Name(1, 63), "my_name", false},
+		{validateRFC1035Name(1, 10), "abcdefghijk", false},
+
+		{validateGCSPath, "gs://bucket", true},
+		{validateGCSPath, "gs://bucket/path/to/object", true},
+		{validateGCSPath, "gs://", false},
+		{validateGCSPath, "gs:///object", false},
+		{validateGCSPath, "bucket/object", false},
+		{validateGCSPath, "https://storage.googleapis.com/bucket", false},
+
+		{validateRegion, "us-central1", true},
+		{validateRegion, "northamerica-northeast1", true},
+		{validateRegion, "us-central1-a", false},
+		{validateRegion, "us-central", false},
+
+		{validateZone, "us-central1-a", true},
+		{validateZone, "europe-west1-d", true},
+		{validateZone, "us-central1", false},
+		{validateZone, "us-central1-ab", false},
+	}
+
+	for i, tc := range cases {
+		_, errors := tc.F(tc.Value, "field")
+		if valid := len(errors) == 0; valid != tc.Valid {
+			t.Fatalf("%d: expected %q valid to be %t, got errors %v", i, tc.Value, tc.Valid, errors)
+		}
+	}
+}
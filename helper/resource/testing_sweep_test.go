@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFilterSweepers(t *testing.T) {
+	source := map[string]*Sweeper{
+		"aws_dummy":    &Sweeper{Name: "aws_dummy"},
+		"aws_top":      &Sweeper{Name: "aws_top"},
+		"aws_sub":      &Sweeper{Name: "aws_sub"},
+		"google_dummy": &Sweeper{Name: "google_dummy"},
+	}
+
+	cases := []struct {
+		Filter   string
+		Expected []string
+	}{
+		{"", []string{"aws_dummy", "aws_sub", "aws_top", "google_dummy"}},
+		{"dummy", []string{"aws_dummy", "google_dummy"}},
+		{"aws_top, google", []string{"aws_top", "google_dummy"}},
+		{"AWS_SUB", []string{"aws_sub"}},
+		{"nothing", []string{}},
+	}
+
+	for _, tc := range cases {
+		names := []string{}
+		for name := range filterSweepers(tc.Filter, source) {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		if !reflect.DeepEqual(names, tc.Expected) {
+			t.Fatalf("%q: expected %v, got %v", tc.Filter, tc.Expected, names)
+		}
+	}
+}
+
+func TestRunSweeperWithRegion(t *testing.T) {
+	old := sweeperFuncs
+	defer func() { sweeperFuncs = old }()
+	sweeperFuncs = make(map[string]*Sweeper)
+
+	var ran []string
+	sweeperFunc := func(name string, err error) SweeperFunc {
+		return func(r string) error {
+			ran = append(ran, fmt.Sprintf("%s/%s", name, r))
+			return err
+		}
+	}
+
+	AddTestSweepers("top", &Sweeper{
+		Name:         "top",
+		Dependencies: []string{"sub", "missing"},
+		F:            sweeperFunc("top", nil),
+	})
+	AddTestSweepers("sub", &Sweeper{
+		Name: "sub",
+		F:    sweeperFunc("sub", nil),
+	})
+	AddTestSweepers("failing", &Sweeper{
+		Name:         "failing",
+		Dependencies: []string{"sub"},
+		F:            sweeperFunc("failing", fmt.Errorf("boom")),
+	})
+
+	runList := map[string]bool{}
+	if err := runSweeperWithRegion("us-central1", sweeperFuncs["top"], runList); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := runSweeperWithRegion("us-central1", sweeperFuncs["failing"], runList); err == nil {
+		t.Fatalf("expected error from failing sweeper")
+	}
+
+	// sub is a dependency of both, but must only run once per region.
+	expected := []string{"sub/us-central1", "top/us-central1", "failing/us-central1"}
+	if !reflect.DeepEqual(ran, expected) {
+		t.Fatalf("expected sweepers to run as %v, got %v", expected, ran)
+	}
+
+	expectedRunList := map[string]bool{"sub": true, "top": true, "failing": false}
+	if !reflect.DeepEqual(runList, expectedRunList) {
+		t.Fatalf("expected run list %v, got %v", expectedRunList, runList)
+	}
+}